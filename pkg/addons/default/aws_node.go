@@ -18,8 +18,7 @@ const (
 	// AWSNode is the name of the aws-node addon
 	AWSNode = "aws-node"
 
-	awsNodeImagePrefix = "602401143452.dkr.ecr."
-	awsNodeImageSuffix = ".amazonaws.com/amazon-k8s-cni"
+	awsNodeImageRepository = "amazon-k8s-cni"
 )
 
 // UpdateAWSNode will update the `aws-node` add-on
@@ -52,10 +51,7 @@ func UpdateAWSNode(rawClient kubernetes.RawClientInterface, region string, plan
 				return false, fmt.Errorf("unexpected image format %q for %q", *image, KubeProxy)
 			}
 
-			if strings.HasPrefix(imageParts[0], awsNodeImagePrefix) &&
-				strings.HasSuffix(imageParts[0], awsNodeImageSuffix) {
-				*image = awsNodeImagePrefix + region + awsNodeImageSuffix + ":" + imageParts[1]
-			}
+			*image = regionalImage(*image, awsNodeImageRepository, region)
 		}
 
 		if resource.GVK.Kind == "CustomResourceDefinition" && plan {
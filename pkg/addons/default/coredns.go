@@ -32,8 +32,7 @@ const (
 
 	componentLabel = "eks.amazonaws.com/component"
 
-	coreDNSImagePrefix = "602401143452.dkr.ecr."
-	coreDNSImageSuffix = ".amazonaws.com/eks/coredns"
+	coreDNSImageRepository = "eks/coredns"
 )
 
 // InstallCoreDNS will install the `coredns` add-on in place of `kube-dns`
@@ -91,10 +90,7 @@ func InstallCoreDNS(rawClient kubernetes.RawClientInterface, region string, wait
 				return false, fmt.Errorf("unexpected image format %q for %q", *image, KubeProxy)
 			}
 
-			if strings.HasPrefix(imageParts[0], coreDNSImagePrefix) &&
-				strings.HasSuffix(imageParts[0], coreDNSImageSuffix) {
-				*image = coreDNSImagePrefix + region + coreDNSImageSuffix + ":" + imageParts[1]
-			}
+			*image = regionalImage(*image, coreDNSImageRepository, region)
 		case "Service":
 			resource.Info.Object.(*corev1.Service).SetResourceVersion(kubeDNSSevice.GetResourceVersion())
 			resource.Info.Object.(*corev1.Service).Spec.ClusterIP = kubeDNSSevice.Spec.ClusterIP
@@ -1,7 +1,11 @@
 package defaultaddons
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,3 +23,18 @@ func LoadAsset(name, ext string) (*metav1.List, error) {
 	}
 	return list, nil
 }
+
+// regionalImage returns the image from the ECR registry of the region, if the image
+// is in the given repository of the EKS registry of any region, otherwise the image
+// is returned as is
+func regionalImage(image, repository, region string) string {
+	imageParts := strings.SplitN(image, "/", 2)
+	if len(imageParts) != 2 || !api.IsEKSImageRegistry(imageParts[0]) {
+		return image
+	}
+	repositoryParts := strings.SplitN(imageParts[1], ":", 2)
+	if len(repositoryParts) != 2 || repositoryParts[0] != repository {
+		return image
+	}
+	return api.EKSImageRegistry(region) + "/" + imageParts[1]
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
@@ -40,11 +42,36 @@ var ImageSearchPatterns = map[string]map[string]map[int]string{
 }
 
 // ImageFamilyToAccountID is a map of image families to account Ids
+// in the commercial partition
 var ImageFamilyToAccountID = map[string]string{
 	ImageFamilyAmazonLinux2: "602401143452",
 	ImageFamilyUbuntu1804:   "099720109477",
 }
 
+// ImageFamilyToAccountIDByPartition is a map of image families to account Ids
+// in partitions other than the commercial one
+var ImageFamilyToAccountIDByPartition = map[string]map[string]string{
+	api.PartitionChina: {
+		ImageFamilyAmazonLinux2: "961992271922",
+		ImageFamilyUbuntu1804:   "837727238323",
+	},
+	api.PartitionUSGov: {
+		ImageFamilyAmazonLinux2: "013241004608",
+		ImageFamilyUbuntu1804:   "513442679011",
+	},
+}
+
+// OwnerAccountID returns the account Id that owns images of the family
+// in the partition of the region
+func OwnerAccountID(region, imageFamily string) (string, bool) {
+	if accountIDs, ok := ImageFamilyToAccountIDByPartition[api.Partition(region)]; ok {
+		accountID, ok := accountIDs[imageFamily]
+		return accountID, ok
+	}
+	accountID, ok := ImageFamilyToAccountID[imageFamily]
+	return accountID, ok
+}
+
 // AutoResolver resolves the AMi to the defaults for the region
 // by querying AWS EC2 API for the AMI to use
 type AutoResolver struct {
@@ -66,7 +93,7 @@ func (r *AutoResolver) Resolve(region, version, instanceType, imageFamily string
 		}
 	}
 
-	ownerAccount, knownOwner := ImageFamilyToAccountID[imageFamily]
+	ownerAccount, knownOwner := OwnerAccountID(region, imageFamily)
 	if !knownOwner {
 		logger.Critical("unable to determine the account owner for image family %s", imageFamily)
 		return "", NewErrFailedResolution(region, version, instanceType, imageFamily)
@@ -41,6 +41,22 @@ var _ = Describe("AMI Auto Resolution", func() {
 			It("should return the Ubuntu Account ID for Ubuntu images", func() {
 				Expect(ImageFamilyToAccountID[ImageFamilyUbuntu1804]).To(BeEquivalentTo("099720109477"))
 			})
+
+			It("should return the AWS Account IDs of the partition of the region", func() {
+				for region, expected := range map[string]string{
+					"eu-west-1":     "602401143452",
+					"cn-north-1":    "961992271922",
+					"us-gov-west-1": "013241004608",
+				} {
+					accountID, ok := OwnerAccountID(region, ImageFamilyAmazonLinux2)
+					Expect(ok).To(BeTrue())
+					Expect(accountID).To(Equal(expected))
+				}
+
+				accountID, ok := OwnerAccountID("us-gov-west-1", ImageFamilyUbuntu1804)
+				Expect(ok).To(BeTrue())
+				Expect(accountID).To(Equal("513442679011"))
+			})
 		})
 
 		Context("with a valid region and N instance type", func() {
@@ -23,6 +23,16 @@ func SetNodeGroupDefaults(_ int, ng *NodeGroup) error {
 	if ng.SecurityGroups.WithShared == nil {
 		ng.SecurityGroups.WithShared = Enabled()
 	}
+	for i := range ng.SecurityGroups.Ingress {
+		rule := &ng.SecurityGroups.Ingress[i]
+		if rule.Protocol == "" {
+			rule.Protocol = DefaultSecurityGroupIngressProtocol
+		}
+		if rule.ToPort == nil {
+			toPort := rule.FromPort
+			rule.ToPort = &toPort
+		}
+	}
 
 	if ng.SSH == nil {
 		ng.SSH = &NodeGroupSSH{
@@ -75,6 +85,30 @@ func SetNodeGroupDefaults(_ int, ng *NodeGroup) error {
 	if ng.IAM.WithAddonPolicies.EFS == nil {
 		ng.IAM.WithAddonPolicies.EFS = Disabled()
 	}
+	if ng.IAM.WithAddonPolicies.CloudWatch == nil {
+		ng.IAM.WithAddonPolicies.CloudWatch = Disabled()
+	}
+	if ng.IAM.WithAddonPolicies.XRay == nil {
+		ng.IAM.WithAddonPolicies.XRay = Disabled()
+	}
+	if ng.IAM.WithAddonPolicies.CertManager == nil {
+		ng.IAM.WithAddonPolicies.CertManager = Disabled()
+	}
 
 	return nil
 }
+
+// SetClusterVPCDefaults will set defaults for VPC configuration of a cluster
+func SetClusterVPCDefaults(vpc *ClusterVPC) {
+	if vpc == nil {
+		return
+	}
+	if vpc.FlowLogs != nil {
+		if vpc.FlowLogs.Destination == "" {
+			vpc.FlowLogs.Destination = FlowLogsDestinationCloudWatchLogs
+		}
+		if vpc.FlowLogs.TrafficType == "" {
+			vpc.FlowLogs.TrafficType = FlowLogsTrafficTypeAll
+		}
+	}
+}
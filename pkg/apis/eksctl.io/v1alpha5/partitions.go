@@ -0,0 +1,82 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PartitionAWS is the partition of the commercial regions
+	PartitionAWS = "aws"
+
+	// PartitionChina is the partition of the regions in China
+	PartitionChina = "aws-cn"
+
+	// PartitionUSGov is the partition of the AWS GovCloud (US) regions
+	PartitionUSGov = "aws-us-gov"
+)
+
+// eksResourceAccountIDs are the accounts that own EKS AMIs and ECR images in each partition
+var eksResourceAccountIDs = map[string]string{
+	PartitionAWS:   "602401143452",
+	PartitionChina: "961992271922",
+	PartitionUSGov: "013241004608",
+}
+
+// Partition returns the partition that the region belongs to
+func Partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionUSGov
+	default:
+		return PartitionAWS
+	}
+}
+
+// PartitionDNSSuffix returns the domain of service endpoints in the partition of the region
+func PartitionDNSSuffix(region string) string {
+	if Partition(region) == PartitionChina {
+		return "amazonaws.com.cn"
+	}
+	return "amazonaws.com"
+}
+
+// ServicePrincipal returns the principal of an AWS service in the partition of the region,
+// e.g. "ec2.amazonaws.com"; only EC2 uses the domain of the partition in China, while
+// other services, such as EKS, use the same principal in all partitions
+func ServicePrincipal(service, region string) string {
+	if service == "ec2" {
+		return fmt.Sprintf("%s.%s", service, PartitionDNSSuffix(region))
+	}
+	return fmt.Sprintf("%s.amazonaws.com", service)
+}
+
+// EKSResourceAccountID returns the account that owns EKS AMIs and ECR images in the
+// partition of the region
+func EKSResourceAccountID(region string) string {
+	return eksResourceAccountIDs[Partition(region)]
+}
+
+// EKSImageRegistry returns the ECR registry with EKS images in the region,
+// e.g. "602401143452.dkr.ecr.us-west-2.amazonaws.com"
+func EKSImageRegistry(region string) string {
+	return fmt.Sprintf("%s.dkr.ecr.%s.%s", EKSResourceAccountID(region), region, PartitionDNSSuffix(region))
+}
+
+// IsEKSImageRegistry checks whether the registry is the ECR registry with EKS images
+// of any region, e.g. one that is set in a manifest for a different region
+func IsEKSImageRegistry(registry string) bool {
+	parts := strings.SplitN(registry, ".", 5)
+	if len(parts) != 5 || parts[1] != "dkr" || parts[2] != "ecr" {
+		return false
+	}
+	return registry == EKSImageRegistry(parts[3])
+}
+
+// PartitionARN returns an ARN in the partition of the region, where resource
+// is the rest of the ARN, e.g. "iam::aws:policy/AmazonEKSClusterPolicy"
+func PartitionARN(region, resource string) string {
+	return fmt.Sprintf("arn:%s:%s", Partition(region), resource)
+}
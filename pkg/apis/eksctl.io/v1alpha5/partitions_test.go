@@ -0,0 +1,41 @@
+package v1alpha5
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Partitions", func() {
+	It("determines the partition from the region", func() {
+		Expect(Partition(RegionUSWest2)).To(Equal(PartitionAWS))
+		Expect(Partition(RegionCNNorthwest1)).To(Equal(PartitionChina))
+		Expect(Partition(RegionUSGovWest1)).To(Equal(PartitionUSGov))
+	})
+
+	It("makes ARNs and service principals of the partition", func() {
+		Expect(PartitionARN(RegionCNNorth1, "iam::aws:policy/AmazonEKS_CNI_Policy")).To(Equal("arn:aws-cn:iam::aws:policy/AmazonEKS_CNI_Policy"))
+		Expect(PartitionARN(RegionUSGovEast1, "route53:::hostedzone/*")).To(Equal("arn:aws-us-gov:route53:::hostedzone/*"))
+
+		Expect(ServicePrincipal("ec2", RegionCNNorth1)).To(Equal("ec2.amazonaws.com.cn"))
+		Expect(ServicePrincipal("ec2", RegionUSGovWest1)).To(Equal("ec2.amazonaws.com"))
+		Expect(ServicePrincipal("eks", RegionCNNorth1)).To(Equal("eks.amazonaws.com"))
+	})
+
+	It("lists supported regions within a partition", func() {
+		Expect(SupportedRegionsInPartition(PartitionChina)).To(ConsistOf(RegionCNNorth1, RegionCNNorthwest1))
+		Expect(SupportedRegionsInPartition(PartitionAWS)).To(ContainElement(RegionEUNorth1))
+		Expect(SupportedRegionsInPartition(PartitionAWS)).ToNot(ContainElement(RegionUSGovWest1))
+	})
+
+	It("recognises EKS image registries of all partitions", func() {
+		Expect(EKSImageRegistry(RegionEUWest2)).To(Equal("602401143452.dkr.ecr.eu-west-2.amazonaws.com"))
+		Expect(EKSImageRegistry(RegionCNNorth1)).To(Equal("961992271922.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+		Expect(EKSImageRegistry(RegionUSGovWest1)).To(Equal("013241004608.dkr.ecr.us-gov-west-1.amazonaws.com"))
+
+		Expect(IsEKSImageRegistry("602401143452.dkr.ecr.eu-west-2.amazonaws.com")).To(BeTrue())
+		Expect(IsEKSImageRegistry("961992271922.dkr.ecr.cn-northwest-1.amazonaws.com.cn")).To(BeTrue())
+		Expect(IsEKSImageRegistry("602401143452.dkr.ecr.cn-north-1.amazonaws.com.cn")).To(BeFalse())
+		Expect(IsEKSImageRegistry("123456789012.dkr.ecr.eu-west-2.amazonaws.com")).To(BeFalse())
+		Expect(IsEKSImageRegistry("docker.io")).To(BeFalse())
+	})
+})
@@ -1,6 +1,7 @@
 package v1alpha5
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -8,10 +9,15 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 const (
@@ -57,6 +63,18 @@ const (
 	// RegionAPSouth1 represents the Asia-Pacific South Region Mumbai
 	RegionAPSouth1 = "ap-south-1"
 
+	// RegionCNNorth1 represents the China North Region Beijing
+	RegionCNNorth1 = "cn-north-1"
+
+	// RegionCNNorthwest1 represents the China North West Region Ningxia
+	RegionCNNorthwest1 = "cn-northwest-1"
+
+	// RegionUSGovWest1 represents the AWS GovCloud (US-West) Region
+	RegionUSGovWest1 = "us-gov-west-1"
+
+	// RegionUSGovEast1 represents the AWS GovCloud (US-East) Region
+	RegionUSGovEast1 = "us-gov-east-1"
+
 	// DefaultRegion defines the default region, where to deploy the EKS cluster
 	DefaultRegion = RegionUSWest2
 
@@ -89,6 +107,9 @@ const (
 	// NodeVolumeTypeST1 is Cold HDD
 	NodeVolumeTypeST1 = "st1"
 
+	// DefaultSecurityGroupIngressProtocol defines the default protocol of additional ingress rules
+	DefaultSecurityGroupIngressProtocol = "tcp"
+
 	// DefaultNodeImageFamily defines the default image family for the worker nodes
 	DefaultNodeImageFamily = NodeImageFamilyAmazonLinux2
 	// NodeImageFamilyAmazonLinux2 represents Amazon Linux 2 family
@@ -122,6 +143,15 @@ const (
 	NodeGroupNameLabel = "alpha.eksctl.io/nodegroup-name"
 )
 
+const (
+	// StackEventsCompact logs each of the stack events on one line
+	StackEventsCompact = "compact"
+	// StackEventsJSON writes stack events to stdout as JSON lines
+	StackEventsJSON = "json"
+	// StackEventsNone disables logging of stack events
+	StackEventsNone = "none"
+)
+
 var (
 	// DefaultWaitTimeout defines the default wait timeout
 	DefaultWaitTimeout = 25 * time.Minute
@@ -169,7 +199,23 @@ func SupportedRegions() []string {
 		RegionAPSouthEast1,
 		RegionAPSouthEast2,
 		RegionAPSouth1,
+		RegionCNNorth1,
+		RegionCNNorthwest1,
+		RegionUSGovWest1,
+		RegionUSGovEast1,
+	}
+}
+
+// SupportedRegionsInPartition are the regions where EKS is available within the given
+// partition, as credentials are only valid within one partition
+func SupportedRegionsInPartition(partition string) []string {
+	regions := []string{}
+	for _, region := range SupportedRegions() {
+		if Partition(region) == partition {
+			regions = append(regions, region)
+		}
 	}
+	return regions
 }
 
 // SupportedVersions are the versions of Kubernetes that EKS supports
@@ -199,6 +245,10 @@ type ClusterMeta struct {
 	Version string `json:"version,omitempty"`
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+	// Protection enables termination protection on all stacks of the cluster,
+	// and prevents control plane and VPC from being replaced or deleted by stack updates
+	// +optional
+	Protection bool `json:"protection,omitempty"`
 }
 
 // ClusterStatus hold read-only attributes of a cluster
@@ -228,9 +278,14 @@ type ClusterProvider interface {
 	STS() stsiface.STSAPI
 	IAM() iamiface.IAMAPI
 	CloudTrail() cloudtrailiface.CloudTrailAPI
+	ELB() elbiface.ELBAPI
+	ELBV2() elbv2iface.ELBV2API
+	S3() s3iface.S3API
 	Region() string
 	Profile() string
 	WaitTimeout() time.Duration
+	StackEventsFormat() string
+	CloudFormationTemplateBucket() string
 }
 
 // ProviderConfig holds global parameters for all interactions with AWS APIs
@@ -240,6 +295,14 @@ type ProviderConfig struct {
 	Region      string
 	Profile     string
 	WaitTimeout time.Duration
+
+	// StackEvents is the format in which CloudFormation stack
+	// events are logged while waiting for stacks
+	StackEvents string
+
+	// CloudFormationTemplateBucket is the S3 bucket where templates are
+	// uploaded to, instead of being passed to CloudFormation inline
+	CloudFormationTemplateBucket string
 }
 
 // +genclient
@@ -254,6 +317,9 @@ type ClusterConfig struct {
 	// +optional
 	IAM ClusterIAM `json:"iam"`
 
+	// +optional
+	IAMIdentityMappings []*IAMIdentityMapping `json:"iamIdentityMappings,omitempty"`
+
 	// +optional
 	VPC *ClusterVPC `json:"vpc,omitempty"`
 
@@ -263,13 +329,83 @@ type ClusterConfig struct {
 	// +optional
 	AvailabilityZones []string `json:"availabilityZones,omitempty"`
 
+	// +optional
+	CloudFormation *CloudFormationExtras `json:"cloudFormation,omitempty"`
+
+	// +optional
+	Kubeconfig *ClusterKubeconfig `json:"kubeconfig,omitempty"`
+
 	Status *ClusterStatus `json:"status,omitempty"`
 }
 
-// ClusterIAM holds all IAM attributes of a cluster
+// ClusterIAM holds all IAM attributes of a cluster, path and permissions
+// boundary apply to all roles eksctl creates, unless set for a nodegroup
 type ClusterIAM struct {
 	// +optional
 	ServiceRoleARN string `json:"serviceRoleARN,omitempty"`
+
+	// +optional
+	ServiceRoleName string `json:"serviceRoleName,omitempty"`
+
+	// Path of roles and instance profiles, e.g. "/eks/"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PermissionsBoundary is the ARN of the managed policy used
+	// as permissions boundary of roles
+	// +optional
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+}
+
+// IAMIdentityMapping maps an IAM role or user to a Kubernetes username and groups,
+// or allows all users of an IAM account to access the cluster, mappings are kept
+// in sync with the aws-auth ConfigMap
+type IAMIdentityMapping struct {
+	// ARN of the role or user, or ID of the account
+	ARN string `json:"arn"`
+
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ClusterKubeconfig holds options for kubeconfig files written for the cluster
+type ClusterKubeconfig struct {
+	// +optional
+	Authenticator *KubeconfigAuthenticator `json:"authenticator,omitempty"`
+}
+
+// KubeconfigAuthenticator configures the exec plugin that kubectl uses to get tokens
+type KubeconfigAuthenticator struct {
+	// Command is aws-iam-authenticator, heptio-authenticator-aws or eksctl,
+	// it's detected when unset
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// RoleARN is the role to assume for generating tokens, e.g. a role
+	// that is shared by cluster administrators
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// Env holds additional environment variables for the command
+	// +optional
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// CloudFormationExtras holds CloudFormation snippets that are added to a stack
+// generated by eksctl, so that custom resources share the lifecycle of the stack;
+// these may refer to resources defined by eksctl using their logical IDs,
+// e.g. ControlPlane, VPC or NodeInstanceRole
+type CloudFormationExtras struct {
+	// ExtraResources are additional resources by logical ID
+	// +optional
+	ExtraResources map[string]json.RawMessage `json:"extraResources,omitempty"`
+
+	// ExtraOutputs are additional outputs by logical ID
+	// +optional
+	ExtraOutputs map[string]json.RawMessage `json:"extraOutputs,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -350,6 +486,9 @@ func (c *ClusterConfig) NewNodeGroup() *NodeGroup {
 				FSX:          Disabled(),
 				EFS:          Disabled(),
 				ALBIngress:   Disabled(),
+				CloudWatch:   Disabled(),
+				XRay:         Disabled(),
+				CertManager:  Disabled(),
 			},
 		},
 		SSH: &NodeGroupSSH{
@@ -419,6 +558,9 @@ type NodeGroup struct {
 
 	// +optional
 	ClusterDNS string `json:"clusterDNS,omitempty"`
+
+	// +optional
+	CloudFormation *CloudFormationExtras `json:"cloudFormation,omitempty"`
 }
 
 // ListOptions returns metav1.ListOptions with label selector for the nodegroup
@@ -437,9 +579,37 @@ type (
 		WithShared *bool `json:"withShared"`
 		// +optional
 		WithLocal *bool `json:"withLocal"`
+		// additional ingress rules for the local security group, if any
+		// of these allows TCP port 22, the default SSH rules are omitted
+		// +optional
+		Ingress []SecurityGroupIngress `json:"ingress,omitempty"`
+	}
+	// SecurityGroupIngress holds an ingress rule of a security group
+	SecurityGroupIngress struct {
+		// +optional
+		Description string `json:"description,omitempty"`
+		// IP protocol name ("tcp", "udp", "icmp" or "icmpv6") or number,
+		// "-1" stands for all protocols, defaults to "tcp"
+		// +optional
+		Protocol string `json:"protocol,omitempty"`
+		// start of the port range
+		FromPort int `json:"fromPort"`
+		// end of the port range, defaults to fromPort
+		// +optional
+		ToPort *int `json:"toPort,omitempty"`
+		// IPv4 or IPv6 CIDRs to allow traffic from
+		// +optional
+		CIDRs []*ipnet.IPNet `json:"cidrs,omitempty"`
+		// IDs of security groups to allow traffic from
+		// +optional
+		SourceSecurityGroupIDs []string `json:"sourceSecurityGroupIDs,omitempty"`
 	}
 	// NodeGroupIAM holds all IAM attributes of a NodeGroup
 	NodeGroupIAM struct {
+		// AttachPolicy holds an IAM policy document that is added to
+		// the stack as an inline policy of the instance role
+		// +optional
+		AttachPolicy json.RawMessage `json:"attachPolicy,omitempty"`
 		// +optional
 		AttachPolicyARNs []string `json:"attachPolicyARNs,omitempty"`
 		// +optional
@@ -449,6 +619,10 @@ type (
 		// +optional
 		InstanceRoleName string `json:"instanceRoleName,omitempty"`
 		// +optional
+		Path string `json:"path,omitempty"`
+		// +optional
+		PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
+		// +optional
 		WithAddonPolicies NodeGroupIAMAddonPolicies `json:"withAddonPolicies,omitempty"`
 	}
 	// NodeGroupIAMAddonPolicies holds all IAM addon policies
@@ -469,6 +643,12 @@ type (
 		EFS *bool `json:"efs"`
 		// +optional
 		ALBIngress *bool `json:"albIngress"`
+		// +optional
+		CloudWatch *bool `json:"cloudWatch"`
+		// +optional
+		XRay *bool `json:"xRay"`
+		// +optional
+		CertManager *bool `json:"certManager"`
 	}
 
 	// NodeGroupSSH holds all the ssh access configuration to a NodeGroup
@@ -1,9 +1,14 @@
 package v1alpha5
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -13,6 +18,15 @@ func validateNodeGroupIAM(i int, ng *NodeGroup, value, fieldName, path string) e
 		if ng.IAM.InstanceRoleName != "" {
 			return fmt.Errorf("%s.instanceRoleName cannot be set at the same time", p)
 		}
+		if ng.IAM.Path != "" {
+			return fmt.Errorf("%s.path cannot be set at the same time", p)
+		}
+		if ng.IAM.PermissionsBoundary != "" {
+			return fmt.Errorf("%s.permissionsBoundary cannot be set at the same time", p)
+		}
+		if len(ng.IAM.AttachPolicy) != 0 {
+			return fmt.Errorf("%s.attachPolicy cannot be set at the same time", p)
+		}
 		if len(ng.IAM.AttachPolicyARNs) != 0 {
 			return fmt.Errorf("%s.attachPolicyARNs cannot be set at the same time", p)
 		}
@@ -40,10 +54,128 @@ func validateNodeGroupIAM(i int, ng *NodeGroup, value, fieldName, path string) e
 		if IsEnabled(ng.IAM.WithAddonPolicies.ALBIngress) {
 			return fmt.Errorf("%s.albIngress cannot be set at the same time", p)
 		}
+		if IsEnabled(ng.IAM.WithAddonPolicies.CloudWatch) {
+			return fmt.Errorf("%s.cloudWatch cannot be set at the same time", p)
+		}
+		if IsEnabled(ng.IAM.WithAddonPolicies.XRay) {
+			return fmt.Errorf("%s.xRay cannot be set at the same time", p)
+		}
+		if IsEnabled(ng.IAM.WithAddonPolicies.CertManager) {
+			return fmt.Errorf("%s.certManager cannot be set at the same time", p)
+		}
 	}
 	return nil
 }
 
+// ValidateClusterVPC checks compatible fields of VPC configuration of a cluster
+func ValidateClusterVPC(vpc *ClusterVPC) error {
+	if vpc == nil {
+		return nil
+	}
+	if err := validateFlowLogs(vpc.FlowLogs); err != nil {
+		return err
+	}
+	if err := validateAttachments(vpc); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateAttachments(vpc *ClusterVPC) error {
+	if len(vpc.Attachments) == 0 {
+		return nil
+	}
+	if vpc.ID != "" || vpc.Selector != nil {
+		return fmt.Errorf("vpc.attachments can only be used with a dedicated VPC, and cannot be set at the same time as vpc.id or vpc.selector")
+	}
+	if vpc.Subnets != nil {
+		for _, subnets := range []map[string]Network{vpc.Subnets.Private, vpc.Subnets.Public} {
+			for _, subnet := range subnets {
+				if subnet.ID != "" {
+					return fmt.Errorf("vpc.attachments can only be used with a dedicated VPC, and cannot be used with existing subnets")
+				}
+			}
+		}
+	}
+	seen := map[string]bool{}
+	for i, a := range vpc.Attachments {
+		path := fmt.Sprintf("vpc.attachments[%d]", i)
+		switch {
+		case a.TransitGatewayID != "" && a.PeeringConnectionID != "":
+			return fmt.Errorf("%s.transitGatewayID and %s.peeringConnectionID cannot be set at the same time", path, path)
+		case a.TransitGatewayID != "":
+			if !strings.HasPrefix(a.TransitGatewayID, "tgw-") {
+				return fmt.Errorf("%s.transitGatewayID %q is not a valid transit gateway ID", path, a.TransitGatewayID)
+			}
+		case a.PeeringConnectionID != "":
+			if !strings.HasPrefix(a.PeeringConnectionID, "pcx-") {
+				return fmt.Errorf("%s.peeringConnectionID %q is not a valid VPC peering connection ID", path, a.PeeringConnectionID)
+			}
+		default:
+			return fmt.Errorf("%s.transitGatewayID or %s.peeringConnectionID must be set", path, path)
+		}
+		id := a.TransitGatewayID + a.PeeringConnectionID
+		if seen[id] {
+			return fmt.Errorf("%s uses %q, which is already used by another attachment", path, id)
+		}
+		seen[id] = true
+		if len(a.DestinationCIDRs) == 0 {
+			return fmt.Errorf("%s.destinationCIDRs must be set", path)
+		}
+		for _, cidr := range a.DestinationCIDRs {
+			if cidr == nil {
+				return fmt.Errorf("%s.destinationCIDRs cannot contain empty values", path)
+			}
+		}
+	}
+	return nil
+}
+
+func validateFlowLogs(flowLogs *VPCFlowLogs) error {
+	if flowLogs == nil {
+		return nil
+	}
+	switch flowLogs.Destination {
+	case "", FlowLogsDestinationCloudWatchLogs:
+		if flowLogs.S3BucketARN != "" {
+			return fmt.Errorf("vpc.flowLogs.s3BucketARN can only be set when vpc.flowLogs.destination is %q", FlowLogsDestinationS3)
+		}
+	case FlowLogsDestinationS3:
+		if flowLogs.S3BucketARN == "" {
+			return fmt.Errorf("vpc.flowLogs.s3BucketARN must be set when vpc.flowLogs.destination is %q", FlowLogsDestinationS3)
+		}
+		if !strings.HasPrefix(flowLogs.S3BucketARN, "arn:") {
+			return fmt.Errorf("vpc.flowLogs.s3BucketARN %q is not a valid ARN", flowLogs.S3BucketARN)
+		}
+		if flowLogs.RetentionInDays != nil {
+			return fmt.Errorf("vpc.flowLogs.retentionInDays cannot be used when vpc.flowLogs.destination is %q", FlowLogsDestinationS3)
+		}
+	default:
+		return fmt.Errorf("vpc.flowLogs.destination %q is invalid, must be one of %q or %q", flowLogs.Destination, FlowLogsDestinationCloudWatchLogs, FlowLogsDestinationS3)
+	}
+	switch flowLogs.TrafficType {
+	case "", FlowLogsTrafficTypeAll, FlowLogsTrafficTypeAccept, FlowLogsTrafficTypeReject:
+	default:
+		return fmt.Errorf("vpc.flowLogs.trafficType %q is invalid, must be one of %q, %q or %q", flowLogs.TrafficType, FlowLogsTrafficTypeAll, FlowLogsTrafficTypeAccept, FlowLogsTrafficTypeReject)
+	}
+	if r := flowLogs.RetentionInDays; r != nil && !isValidLogRetention(*r) {
+		return fmt.Errorf("vpc.flowLogs.retentionInDays %d is invalid, must be one of %v", *r, validLogRetentionDays)
+	}
+	return nil
+}
+
+// validLogRetentionDays are the values accepted by CloudWatch Logs
+var validLogRetentionDays = []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653}
+
+func isValidLogRetention(days int) bool {
+	for _, d := range validLogRetentionDays {
+		if days == d {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateNodeGroupLabels uses proper Kubernetes label validation,
 // it's designed to make sure users don't pass weird labels to the
 // nodes, which would prevent kubelets to startup properly
@@ -120,6 +252,14 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 		return fmt.Errorf("%s.name must be set", path)
 	}
 
+	if err := validateNodeGroupSecurityGroups(ng.SecurityGroups, path); err != nil {
+		return err
+	}
+
+	if err := ValidateCloudFormationExtras(ng.CloudFormation, path+".cloudFormation"); err != nil {
+		return err
+	}
+
 	if ng.IAM == nil {
 		return nil
 	}
@@ -130,6 +270,12 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 	if err := validateNodeGroupIAM(i, ng, ng.IAM.InstanceRoleARN, "instanceRoleARN", path); err != nil {
 		return err
 	}
+	if err := validateIAMPathAndBoundary(ng.IAM.Path, ng.IAM.PermissionsBoundary, path+".iam"); err != nil {
+		return err
+	}
+	if err := validatePolicyDocument(ng.IAM.AttachPolicy, path+".iam.attachPolicy"); err != nil {
+		return err
+	}
 
 	if err := ValidateNodeGroupLabels(ng); err != nil {
 		return err
@@ -141,6 +287,216 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 	return nil
 }
 
+// validatePolicyDocument checks that a policy document is an object with statements,
+// IAM will check the statements themselves when the stack is created
+func validatePolicyDocument(document json.RawMessage, path string) error {
+	if len(document) == 0 {
+		return nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return fmt.Errorf("%s must be an object", path)
+	}
+	var statements []map[string]json.RawMessage
+	if err := json.Unmarshal(fields["Statement"], &statements); err == nil && len(statements) > 0 {
+		return nil
+	}
+	var statement map[string]json.RawMessage
+	if err := json.Unmarshal(fields["Statement"], &statement); err == nil && len(statement) > 0 {
+		return nil
+	}
+	return fmt.Errorf("%s.Statement must be set to a statement or a list of statements", path)
+}
+
+func validateNodeGroupSecurityGroups(sgs *NodeGroupSGs, path string) error {
+	if sgs == nil || len(sgs.Ingress) == 0 {
+		return nil
+	}
+	if sgs.WithLocal != nil && !*sgs.WithLocal {
+		return fmt.Errorf("%s.securityGroups.ingress cannot be used when %s.securityGroups.withLocal is disabled", path, path)
+	}
+	for i, rule := range sgs.Ingress {
+		p := fmt.Sprintf("%s.securityGroups.ingress[%d]", path, i)
+		if len(rule.CIDRs) == 0 && len(rule.SourceSecurityGroupIDs) == 0 {
+			return fmt.Errorf("%s.cidrs or %s.sourceSecurityGroupIDs must be set", p, p)
+		}
+		for _, cidr := range rule.CIDRs {
+			if cidr == nil {
+				return fmt.Errorf("%s.cidrs cannot contain empty values", p)
+			}
+		}
+		for _, id := range rule.SourceSecurityGroupIDs {
+			if !strings.HasPrefix(id, "sg-") {
+				return fmt.Errorf("%s.sourceSecurityGroupIDs contains %q, which is not a valid security group ID", p, id)
+			}
+		}
+		if !isValidIPProtocol(rule.Protocol) {
+			return fmt.Errorf("%s.protocol %q is invalid, must be one of \"tcp\", \"udp\", \"icmp\", \"icmpv6\", \"-1\" or a protocol number", p, rule.Protocol)
+		}
+		if err := validateIngressPorts(rule, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateIngressPorts checks ports of a rule as EC2 interprets them, i.e. for ICMP these
+// are the type and code, where -1 means all, and for protocols other than TCP, UDP and
+// ICMP, including "-1" (all traffic), ports are ignored
+func validateIngressPorts(rule SecurityGroupIngress, path string) error {
+	toPort := rule.FromPort
+	if rule.ToPort != nil {
+		toPort = *rule.ToPort
+	}
+	switch rule.Protocol {
+	case "", "tcp", "6", "udp", "17":
+		if rule.FromPort < 0 || rule.FromPort > 65535 || toPort < 0 || toPort > 65535 {
+			return fmt.Errorf("%s has invalid port range %d-%d, ports must be between 0 and 65535", path, rule.FromPort, toPort)
+		}
+		if rule.FromPort > toPort {
+			return fmt.Errorf("%s.fromPort (%d) cannot be greater than %s.toPort (%d)", path, rule.FromPort, path, toPort)
+		}
+	case "icmp", "1", "icmpv6", "58":
+		if rule.FromPort < -1 || rule.FromPort > 255 || toPort < -1 || toPort > 255 {
+			return fmt.Errorf("%s has invalid ICMP type %d or code %d, these must be between 0 and 255, or -1 for all", path, rule.FromPort, toPort)
+		}
+	}
+	return nil
+}
+
+var (
+	cloudFormationLogicalIDRE = regexp.MustCompile("^[A-Za-z0-9]+$")
+
+	cloudFormationResourceAttributes = []string{"Type", "Properties", "DependsOn", "Condition", "Metadata",
+		"DeletionPolicy", "UpdateReplacePolicy", "CreationPolicy", "UpdatePolicy"}
+	cloudFormationOutputAttributes = []string{"Value", "Description", "Export", "Condition"}
+)
+
+// ValidateCloudFormationExtras checks that extra resources and outputs are well-formed;
+// whether these refer to existing resources is only known when the template is built
+func ValidateCloudFormationExtras(extras *CloudFormationExtras, path string) error {
+	if extras == nil {
+		return nil
+	}
+	if err := validateCloudFormationSnippets(extras.ExtraResources, path+".extraResources", "Type", cloudFormationResourceAttributes); err != nil {
+		return err
+	}
+	return validateCloudFormationSnippets(extras.ExtraOutputs, path+".extraOutputs", "Value", cloudFormationOutputAttributes)
+}
+
+func validateCloudFormationSnippets(snippets map[string]json.RawMessage, path, required string, attributes []string) error {
+	ids := []string{}
+	for id := range snippets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p := fmt.Sprintf("%s.%s", path, id)
+		if !cloudFormationLogicalIDRE.MatchString(id) {
+			return fmt.Errorf("%s has invalid logical ID, only alphanumeric characters are allowed", p)
+		}
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(snippets[id], &fields); err != nil {
+			return fmt.Errorf("%s must be an object", p)
+		}
+		if _, ok := fields[required]; !ok {
+			return fmt.Errorf("%s.%s must be set", p, required)
+		}
+		for k := range fields {
+			if !containsString(attributes, k) {
+				return fmt.Errorf("%s.%s is not supported, must be one of %v", p, k, attributes)
+			}
+		}
+		if required == "Type" {
+			var resourceType string
+			if err := json.Unmarshal(fields[required], &resourceType); err != nil || !strings.Contains(resourceType, "::") {
+				return fmt.Errorf("%s.Type must be a resource type, e.g. \"AWS::SQS::Queue\"", p)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateIAMIdentityMappings checks that each mapping has an ARN, and that no ARN is
+// mapped twice; the kind of identity is determined from the ARN when mappings are synced
+func ValidateIAMIdentityMappings(mappings []*IAMIdentityMapping) error {
+	seen := map[string]bool{}
+	for i, m := range mappings {
+		path := fmt.Sprintf("iamIdentityMappings[%d]", i)
+		if m == nil || m.ARN == "" {
+			return fmt.Errorf("%s.arn must be set", path)
+		}
+		if seen[m.ARN] {
+			return fmt.Errorf("%s.arn %q is mapped more than once", path, m.ARN)
+		}
+		seen[m.ARN] = true
+	}
+	return nil
+}
+
+// iamPathPattern is based on the pattern of paths in IAM API reference
+var iamPathPattern = regexp.MustCompile(`^/([\x21-\x7E]+/)?$`)
+
+// ValidateClusterIAM checks that the service role is either given or named, and
+// that path and permissions boundary are valid
+func ValidateClusterIAM(iam *ClusterIAM) error {
+	if iam.ServiceRoleARN != "" && iam.ServiceRoleName != "" {
+		return fmt.Errorf("iam.serviceRoleARN and iam.serviceRoleName cannot be set at the same time")
+	}
+	return validateIAMPathAndBoundary(iam.Path, iam.PermissionsBoundary, "iam")
+}
+
+func validateIAMPathAndBoundary(iamPath, permissionsBoundary, path string) error {
+	if iamPath != "" && (len(iamPath) > 512 || !iamPathPattern.MatchString(iamPath)) {
+		return fmt.Errorf("%s.path %q must begin and end with \"/\"", path, iamPath)
+	}
+	if permissionsBoundary != "" {
+		if _, err := arn.Parse(permissionsBoundary); err != nil {
+			return fmt.Errorf("%s.permissionsBoundary %q is not an ARN", path, permissionsBoundary)
+		}
+	}
+	return nil
+}
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateKubeconfigAuthenticator checks that the role is an ARN, and that environment
+// variable names are valid
+func ValidateKubeconfigAuthenticator(authenticator *KubeconfigAuthenticator, path string) error {
+	if authenticator == nil {
+		return nil
+	}
+	if authenticator.RoleARN != "" {
+		if _, err := arn.Parse(authenticator.RoleARN); err != nil {
+			return fmt.Errorf("%s.roleARN %q is not an ARN", path, authenticator.RoleARN)
+		}
+	}
+	for name := range authenticator.Env {
+		if !envVarNamePattern.MatchString(name) {
+			return fmt.Errorf("%s.env has invalid variable name %q", path, name)
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidIPProtocol(protocol string) bool {
+	switch protocol {
+	case "", "tcp", "udp", "icmp", "icmpv6", "-1":
+		return true
+	}
+	n, err := strconv.Atoi(protocol)
+	return err == nil && n >= 0 && n <= 255
+}
+
 func validateNodeGroupSSH(SSH *NodeGroupSSH) error {
 	if SSH == nil {
 		return nil
@@ -1,8 +1,12 @@
 package v1alpha5
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("ConfigFile ssh flags validation", func() {
@@ -52,3 +56,326 @@ func checkItDetectsError(SSHConfig *NodeGroupSSH) {
 	err := validateNodeGroupSSH(SSHConfig)
 	Expect(err).To(HaveOccurred())
 }
+
+var _ = Describe("ClusterConfig VPC flow logs validation", func() {
+	It("accepts defaults", func() {
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts S3 destination with a bucket ARN", func() {
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{
+			Destination: FlowLogsDestinationS3,
+			S3BucketARN: "arn:aws:s3:::flow-logs",
+		}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when S3 destination has no bucket ARN", func() {
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{
+			Destination: FlowLogsDestinationS3,
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when retention is used with S3 destination", func() {
+		retention := 7
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{
+			Destination:     FlowLogsDestinationS3,
+			S3BucketARN:     "arn:aws:s3:::flow-logs",
+			RetentionInDays: &retention,
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when retention is not supported by CloudWatch Logs", func() {
+		retention := 10
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{
+			RetentionInDays: &retention,
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when traffic type is unknown", func() {
+		err := ValidateClusterVPC(&ClusterVPC{FlowLogs: &VPCFlowLogs{
+			TrafficType: "SOME",
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ClusterConfig VPC attachments validation", func() {
+	cidr, _ := ipnet.ParseCIDR("10.0.0.0/8")
+
+	It("accepts a transit gateway", func() {
+		err := ValidateClusterVPC(&ClusterVPC{Attachments: []VPCAttachment{
+			{TransitGatewayID: "tgw-123", DestinationCIDRs: []*ipnet.IPNet{cidr}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when both transit gateway and peering connection are set", func() {
+		err := ValidateClusterVPC(&ClusterVPC{Attachments: []VPCAttachment{
+			{TransitGatewayID: "tgw-123", PeeringConnectionID: "pcx-123", DestinationCIDRs: []*ipnet.IPNet{cidr}},
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when no destination CIDRs are set", func() {
+		err := ValidateClusterVPC(&ClusterVPC{Attachments: []VPCAttachment{
+			{PeeringConnectionID: "pcx-123"},
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when the same transit gateway is used twice", func() {
+		err := ValidateClusterVPC(&ClusterVPC{Attachments: []VPCAttachment{
+			{TransitGatewayID: "tgw-123", DestinationCIDRs: []*ipnet.IPNet{cidr}},
+			{TransitGatewayID: "tgw-123", DestinationCIDRs: []*ipnet.IPNet{cidr}},
+		}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails when used with an existing VPC", func() {
+		err := ValidateClusterVPC(&ClusterVPC{
+			Network: Network{ID: "vpc-123"},
+			Attachments: []VPCAttachment{
+				{TransitGatewayID: "tgw-123", DestinationCIDRs: []*ipnet.IPNet{cidr}},
+			},
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("NodeGroup security group ingress validation", func() {
+	cidr, _ := ipnet.ParseCIDR("203.0.113.10/32")
+
+	var ng *NodeGroup
+
+	BeforeEach(func() {
+		ng = NewClusterConfig().NewNodeGroup()
+		ng.Name = "ng"
+	})
+
+	It("accepts SSH from a bastion CIDR", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 22, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+	})
+
+	It("accepts protocol numbers and source security groups", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{Protocol: "50", SourceSecurityGroupIDs: []string{"sg-123"}}}
+		Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+	})
+
+	It("fails when neither CIDRs nor source security groups are set", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 22}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("fails with an invalid port range", func() {
+		toPort := 80
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 443, ToPort: &toPort, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 70000, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("accepts all ICMP types and ignores ports of all traffic", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{
+			{Protocol: "icmp", FromPort: -1, CIDRs: []*ipnet.IPNet{cidr}},
+			{Protocol: "icmpv6", FromPort: 128, CIDRs: []*ipnet.IPNet{cidr}},
+			{Protocol: "-1", FromPort: -1, CIDRs: []*ipnet.IPNet{cidr}},
+		}
+		Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{Protocol: "icmp", FromPort: 300, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("fails with an unknown protocol", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{Protocol: "sctp", FromPort: 22, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("fails when the local security group is disabled", func() {
+		ng.SecurityGroups.WithLocal = Disabled()
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 22, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("sets default protocol and port range", func() {
+		ng.SecurityGroups.Ingress = []SecurityGroupIngress{{FromPort: 22, CIDRs: []*ipnet.IPNet{cidr}}}
+		Expect(SetNodeGroupDefaults(0, ng)).To(Succeed())
+		Expect(ng.SecurityGroups.Ingress[0].Protocol).To(Equal("tcp"))
+		Expect(*ng.SecurityGroups.Ingress[0].ToPort).To(Equal(22))
+	})
+})
+
+var _ = Describe("CloudFormation extras validation", func() {
+	It("accepts resources and outputs", func() {
+		extras := &CloudFormationExtras{
+			ExtraResources: map[string]json.RawMessage{
+				"Queue": json.RawMessage(`{"Type": "AWS::SQS::Queue", "DeletionPolicy": "Retain"}`),
+			},
+			ExtraOutputs: map[string]json.RawMessage{
+				"QueueURL": json.RawMessage(`{"Value": {"Ref": "Queue"}, "Export": {"Name": "queue"}}`),
+			},
+		}
+		Expect(ValidateCloudFormationExtras(extras, "cloudFormation")).To(Succeed())
+	})
+
+	It("fails with invalid logical IDs", func() {
+		extras := &CloudFormationExtras{
+			ExtraResources: map[string]json.RawMessage{
+				"my-queue": json.RawMessage(`{"Type": "AWS::SQS::Queue"}`),
+			},
+		}
+		Expect(ValidateCloudFormationExtras(extras, "cloudFormation")).To(MatchError(
+			"cloudFormation.extraResources.my-queue has invalid logical ID, only alphanumeric characters are allowed"))
+	})
+
+	It("fails when resources have no type or unknown attributes", func() {
+		ng := NewClusterConfig().NewNodeGroup()
+		ng.Name = "ng"
+		ng.CloudFormation = &CloudFormationExtras{
+			ExtraResources: map[string]json.RawMessage{
+				"Queue": json.RawMessage(`{"Properties": {}}`),
+			},
+		}
+		Expect(ValidateNodeGroup(0, ng)).To(MatchError("nodegroups[0].cloudFormation.extraResources.Queue.Type must be set"))
+
+		ng.CloudFormation.ExtraResources["Queue"] = json.RawMessage(`{"Type": "Queue"}`)
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+		ng.CloudFormation.ExtraResources["Queue"] = json.RawMessage(`{"Type": "AWS::SQS::Queue", "Propertes": {}}`)
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+		ng.CloudFormation.ExtraResources["Queue"] = json.RawMessage(`["AWS::SQS::Queue"]`)
+		Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+	})
+
+	It("fails when outputs have no value", func() {
+		extras := &CloudFormationExtras{
+			ExtraOutputs: map[string]json.RawMessage{
+				"QueueURL": json.RawMessage(`{"Description": "URL"}`),
+			},
+		}
+		Expect(ValidateCloudFormationExtras(extras, "cloudFormation")).To(MatchError("cloudFormation.extraOutputs.QueueURL.Value must be set"))
+	})
+})
+
+var _ = Describe("IAM identity mappings validation", func() {
+	It("accepts distinct mappings", func() {
+		err := ValidateIAMIdentityMappings([]*IAMIdentityMapping{
+			{ARN: "arn:aws:iam::123456789012:role/admins", Username: "admin", Groups: []string{"system:masters"}},
+			{ARN: "123456789012"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when the ARN is not set", func() {
+		err := ValidateIAMIdentityMappings([]*IAMIdentityMapping{{Username: "admin"}})
+		Expect(err).To(MatchError("iamIdentityMappings[0].arn must be set"))
+	})
+
+	It("fails when an ARN is mapped twice", func() {
+		err := ValidateIAMIdentityMappings([]*IAMIdentityMapping{
+			{ARN: "arn:aws:iam::123456789012:role/admins", Username: "admin"},
+			{ARN: "arn:aws:iam::123456789012:role/admins", Username: "other"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("iamIdentityMappings[1].arn")))
+	})
+})
+
+var _ = Describe("Kubeconfig authenticator validation", func() {
+	It("accepts a role ARN and environment variables", func() {
+		err := ValidateKubeconfigAuthenticator(&KubeconfigAuthenticator{
+			RoleARN: "arn:aws:iam::123456789012:role/admins",
+			Env:     map[string]string{"AWS_STS_REGIONAL_ENDPOINTS": "regional"},
+		}, "kubeconfig.authenticator")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when the role is not an ARN", func() {
+		err := ValidateKubeconfigAuthenticator(&KubeconfigAuthenticator{RoleARN: "admins"}, "kubeconfig.authenticator")
+		Expect(err).To(MatchError(`kubeconfig.authenticator.roleARN "admins" is not an ARN`))
+	})
+
+	It("fails when an environment variable name is invalid", func() {
+		err := ValidateKubeconfigAuthenticator(&KubeconfigAuthenticator{Env: map[string]string{"AWS PROFILE": "x"}}, "kubeconfig.authenticator")
+		Expect(err).To(MatchError(ContainSubstring("invalid variable name")))
+	})
+})
+
+var _ = Describe("IAM path and permissions boundary validation", func() {
+	It("accepts a path and a permissions boundary", func() {
+		err := ValidateClusterIAM(&ClusterIAM{Path: "/eks/admin/", PermissionsBoundary: "arn:aws:iam::123456789012:policy/boundary"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("fails when service role is given and named", func() {
+		err := ValidateClusterIAM(&ClusterIAM{ServiceRoleARN: "arn:aws:iam::123456789012:role/eks", ServiceRoleName: "eks"})
+		Expect(err).To(MatchError("iam.serviceRoleARN and iam.serviceRoleName cannot be set at the same time"))
+	})
+
+	It("fails when the path does not end with a slash", func() {
+		err := ValidateClusterIAM(&ClusterIAM{Path: "/eks"})
+		Expect(err).To(MatchError(`iam.path "/eks" must begin and end with "/"`))
+	})
+
+	It("fails when a nodegroup with a given instance role has a path", func() {
+		ng := NewClusterConfig().NewNodeGroup()
+		ng.Name = "ng"
+		ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/nodes"
+		ng.IAM.Path = "/eks/"
+		err := ValidateNodeGroup(0, ng)
+		Expect(err).To(MatchError(ContainSubstring(".path cannot be set at the same time")))
+	})
+
+	It("fails when the permissions boundary of a nodegroup is not an ARN", func() {
+		ng := NewClusterConfig().NewNodeGroup()
+		ng.Name = "ng"
+		ng.IAM.PermissionsBoundary = "boundary"
+		err := ValidateNodeGroup(0, ng)
+		Expect(err).To(MatchError(`nodegroups[0].iam.permissionsBoundary "boundary" is not an ARN`))
+	})
+})
+
+var _ = Describe("IAM inline policy validation", func() {
+	var ng *NodeGroup
+
+	BeforeEach(func() {
+		ng = NewClusterConfig().NewNodeGroup()
+		ng.Name = "ng"
+	})
+
+	It("accepts a policy document with a list of statements", func() {
+		ng.IAM.AttachPolicy = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}]}`)
+		Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+	})
+
+	It("accepts a policy document with a single statement", func() {
+		ng.IAM.AttachPolicy = []byte(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}}`)
+		Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+	})
+
+	It("fails when there are no statements", func() {
+		ng.IAM.AttachPolicy = []byte(`{"Version": "2012-10-17"}`)
+		err := ValidateNodeGroup(0, ng)
+		Expect(err).To(MatchError("nodegroups[0].iam.attachPolicy.Statement must be set to a statement or a list of statements"))
+	})
+
+	It("fails when the document is not an object", func() {
+		ng.IAM.AttachPolicy = []byte(`["s3:GetObject"]`)
+		err := ValidateNodeGroup(0, ng)
+		Expect(err).To(MatchError("nodegroups[0].iam.attachPolicy must be an object"))
+	})
+
+	It("fails when an instance role is given", func() {
+		ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/nodes"
+		ng.IAM.WithAddonPolicies.CertManager = Enabled()
+		err := ValidateNodeGroup(0, ng)
+		Expect(err).To(MatchError("nodegroups[0].iam.instanceRoleARN and nodegroups[0].iam.certManager cannot be set at the same time"))
+	})
+})
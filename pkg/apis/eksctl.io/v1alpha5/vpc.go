@@ -24,6 +24,59 @@ type (
 		ExtraCIDRs []*ipnet.IPNet `json:"extraCIDRs,omitempty"`
 		// for pre-defined shared node SG
 		SharedNodeSecurityGroup string `json:"sharedNodeSecurityGroup,omitempty"`
+		// for discovering an existing VPC and its subnets by tags,
+		// instead of specifying the IDs explicitly
+		// +optional
+		Selector *VPCSelector `json:"selector,omitempty"`
+		// for enabling VPC flow logs
+		// +optional
+		FlowLogs *VPCFlowLogs `json:"flowLogs,omitempty"`
+		// for routing traffic to other networks via transit gateways
+		// or VPC peering connections, can only be used with a dedicated VPC
+		// +optional
+		Attachments []VPCAttachment `json:"attachments,omitempty"`
+	}
+	// VPCSelector holds tag filters used to discover an existing VPC
+	// and its subnets
+	VPCSelector struct {
+		// tags the VPC must have
+		// +optional
+		Tags map[string]string `json:"tags,omitempty"`
+		// tags the subnets must have, when unset all subnets
+		// of the VPC are considered
+		// +optional
+		SubnetTags map[string]string `json:"subnetTags,omitempty"`
+	}
+	// VPCFlowLogs holds the configuration of VPC flow logs
+	VPCFlowLogs struct {
+		// where the logs are delivered to, either "cloud-watch-logs" or "s3",
+		// defaults to "cloud-watch-logs"
+		// +optional
+		Destination string `json:"destination,omitempty"`
+		// ARN of an existing S3 bucket (with an optional folder),
+		// must be set when destination is "s3"
+		// +optional
+		S3BucketARN string `json:"s3BucketARN,omitempty"`
+		// type of traffic to log, either "ALL", "ACCEPT" or "REJECT",
+		// defaults to "ALL"
+		// +optional
+		TrafficType string `json:"trafficType,omitempty"`
+		// number of days to retain the logs for, can only be
+		// used with "cloud-watch-logs" destination
+		// +optional
+		RetentionInDays *int `json:"retentionInDays,omitempty"`
+	}
+	// VPCAttachment holds a transit gateway or a VPC peering connection,
+	// and the destination CIDRs to route through it
+	VPCAttachment struct {
+		// ID of a transit gateway to attach the VPC to
+		// +optional
+		TransitGatewayID string `json:"transitGatewayID,omitempty"`
+		// ID of an existing VPC peering connection
+		// +optional
+		PeeringConnectionID string `json:"peeringConnectionID,omitempty"`
+		// CIDRs that are routed via the transit gateway or peering connection
+		DestinationCIDRs []*ipnet.IPNet `json:"destinationCIDRs"`
 	}
 	// ClusterSubnets holds private and public subnets
 	ClusterSubnets struct {
@@ -50,6 +103,18 @@ const (
 	SubnetTopologyPrivate SubnetTopology = "Private"
 	// SubnetTopologyPublic represents publicly-routed subnets
 	SubnetTopologyPublic SubnetTopology = "Public"
+
+	// FlowLogsDestinationCloudWatchLogs delivers flow logs to a CloudWatch Logs log group
+	FlowLogsDestinationCloudWatchLogs = "cloud-watch-logs"
+	// FlowLogsDestinationS3 delivers flow logs to an S3 bucket
+	FlowLogsDestinationS3 = "s3"
+
+	// FlowLogsTrafficTypeAll logs all traffic
+	FlowLogsTrafficTypeAll = "ALL"
+	// FlowLogsTrafficTypeAccept logs only accepted traffic
+	FlowLogsTrafficTypeAccept = "ACCEPT"
+	// FlowLogsTrafficTypeReject logs only rejected traffic
+	FlowLogsTrafficTypeReject = "REJECT"
 )
 
 // SubnetTopologies returns a list of topologies
@@ -21,10 +21,58 @@ limitations under the License.
 package v1alpha5
 
 import (
+	json "encoding/json"
+
 	ipnet "github.com/weaveworks/eksctl/pkg/utils/ipnet"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudFormationExtras) DeepCopyInto(out *CloudFormationExtras) {
+	*out = *in
+	if in.ExtraResources != nil {
+		in, out := &in.ExtraResources, &out.ExtraResources
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ExtraOutputs != nil {
+		in, out := &in.ExtraOutputs, &out.ExtraOutputs
+		*out = make(map[string]json.RawMessage, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudFormationExtras.
+func (in *CloudFormationExtras) DeepCopy() *CloudFormationExtras {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudFormationExtras)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -35,6 +83,17 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.IAM = in.IAM
+	if in.IAMIdentityMappings != nil {
+		in, out := &in.IAMIdentityMappings, &out.IAMIdentityMappings
+		*out = make([]*IAMIdentityMapping, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(IAMIdentityMapping)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.VPC != nil {
 		in, out := &in.VPC, &out.VPC
 		*out = new(ClusterVPC)
@@ -56,6 +115,16 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CloudFormation != nil {
+		in, out := &in.CloudFormation, &out.CloudFormation
+		*out = new(CloudFormationExtras)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(ClusterKubeconfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(ClusterStatus)
@@ -131,6 +200,27 @@ func (in *ClusterIAM) DeepCopy() *ClusterIAM {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterKubeconfig) DeepCopyInto(out *ClusterKubeconfig) {
+	*out = *in
+	if in.Authenticator != nil {
+		in, out := &in.Authenticator, &out.Authenticator
+		*out = new(KubeconfigAuthenticator)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterKubeconfig.
+func (in *ClusterKubeconfig) DeepCopy() *ClusterKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterMeta) DeepCopyInto(out *ClusterMeta) {
 	*out = *in
@@ -224,6 +314,23 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 			}
 		}
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(VPCSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(VPCFlowLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]VPCAttachment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -237,6 +344,50 @@ func (in *ClusterVPC) DeepCopy() *ClusterVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMIdentityMapping) DeepCopyInto(out *IAMIdentityMapping) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMIdentityMapping.
+func (in *IAMIdentityMapping) DeepCopy() *IAMIdentityMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMIdentityMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigAuthenticator) DeepCopyInto(out *KubeconfigAuthenticator) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigAuthenticator.
+func (in *KubeconfigAuthenticator) DeepCopy() *KubeconfigAuthenticator {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigAuthenticator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -331,6 +482,11 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CloudFormation != nil {
+		in, out := &in.CloudFormation, &out.CloudFormation
+		*out = new(CloudFormationExtras)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -347,6 +503,11 @@ func (in *NodeGroup) DeepCopy() *NodeGroup {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupIAM) DeepCopyInto(out *NodeGroupIAM) {
 	*out = *in
+	if in.AttachPolicy != nil {
+		in, out := &in.AttachPolicy, &out.AttachPolicy
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
 	if in.AttachPolicyARNs != nil {
 		in, out := &in.AttachPolicyARNs, &out.AttachPolicyARNs
 		*out = make([]string, len(*in))
@@ -409,6 +570,21 @@ func (in *NodeGroupIAMAddonPolicies) DeepCopyInto(out *NodeGroupIAMAddonPolicies
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.XRay != nil {
+		in, out := &in.XRay, &out.XRay
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -440,6 +616,13 @@ func (in *NodeGroupSGs) DeepCopyInto(out *NodeGroupSGs) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]SecurityGroupIngress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -504,3 +687,116 @@ func (in *ProviderConfig) DeepCopy() *ProviderConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupIngress) DeepCopyInto(out *SecurityGroupIngress) {
+	*out = *in
+	if in.ToPort != nil {
+		in, out := &in.ToPort, &out.ToPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]*ipnet.IPNet, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = (*in).DeepCopy()
+			}
+		}
+	}
+	if in.SourceSecurityGroupIDs != nil {
+		in, out := &in.SourceSecurityGroupIDs, &out.SourceSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupIngress.
+func (in *SecurityGroupIngress) DeepCopy() *SecurityGroupIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCAttachment) DeepCopyInto(out *VPCAttachment) {
+	*out = *in
+	if in.DestinationCIDRs != nil {
+		in, out := &in.DestinationCIDRs, &out.DestinationCIDRs
+		*out = make([]*ipnet.IPNet, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = (*in).DeepCopy()
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCAttachment.
+func (in *VPCAttachment) DeepCopy() *VPCAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCFlowLogs) DeepCopyInto(out *VPCFlowLogs) {
+	*out = *in
+	if in.RetentionInDays != nil {
+		in, out := &in.RetentionInDays, &out.RetentionInDays
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCFlowLogs.
+func (in *VPCFlowLogs) DeepCopy() *VPCFlowLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCFlowLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCSelector) DeepCopyInto(out *VPCSelector) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SubnetTags != nil {
+		in, out := &in.SubnetTags, &out.SubnetTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSelector.
+func (in *VPCSelector) DeepCopy() *VPCSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCSelector)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -8,7 +8,9 @@ package authconfigmap
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
@@ -33,6 +35,7 @@ const (
 	ObjectNamespace = metav1.NamespaceSystem
 
 	rolesData    = "mapRoles"
+	usersData    = "mapUsers"
 	accountsData = "mapAccounts"
 
 	// GroupMasters is the admin group which is also automatically
@@ -154,6 +157,20 @@ func (a *AuthConfigMap) AddRole(arn string, username string, groups []string) er
 	return a.setRoles(roles)
 }
 
+// HasRole checks whether the given IAM role is already mapped
+func (a *AuthConfigMap) HasRole(arn string) (bool, error) {
+	roles, err := a.roles()
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if role["rolearn"] == arn {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // RemoveRole removes exactly one entry, even if there are duplicates.
 // If it cannot find the role it returns an error.
 func (a *AuthConfigMap) RemoveRole(arn string) error {
@@ -193,6 +210,72 @@ func (a *AuthConfigMap) setRoles(r mapRoles) error {
 	return nil
 }
 
+// AddUser maps an IAM user to a k8s username and groups, like AddRole
+// it doesn't check whether the user is already mapped.
+func (a *AuthConfigMap) AddUser(arn string, username string, groups []string) error {
+	users, err := a.users()
+	if err != nil {
+		return err
+	}
+	users = append(users, mapRole{
+		"userarn":  arn,
+		"username": username,
+		"groups":   groups,
+	})
+	logger.Info("adding user %q to auth ConfigMap", arn)
+	return a.setUsers(users)
+}
+
+// HasUser checks whether the given IAM user is already mapped
+func (a *AuthConfigMap) HasUser(arn string) (bool, error) {
+	users, err := a.users()
+	if err != nil {
+		return false, err
+	}
+	for _, user := range users {
+		if user["userarn"] == arn {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RemoveUser removes exactly one entry, even if there are duplicates.
+// If it cannot find the user it returns an error.
+func (a *AuthConfigMap) RemoveUser(arn string) error {
+	users, err := a.users()
+	if err != nil {
+		return err
+	}
+
+	for i, user := range users {
+		if user["userarn"] == arn {
+			logger.Info("removing user %q from auth ConfigMap", arn)
+			users = append(users[:i], users[i+1:]...)
+			return a.setUsers(users)
+		}
+	}
+
+	return fmt.Errorf("user ARN %q not found in auth ConfigMap", arn)
+}
+
+func (a *AuthConfigMap) users() (mapRoles, error) {
+	var users mapRoles
+	if err := yaml.Unmarshal([]byte(a.cm.Data[usersData]), &users); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling mapUsers")
+	}
+	return users, nil
+}
+
+func (a *AuthConfigMap) setUsers(u mapRoles) error {
+	bs, err := yaml.Marshal(u)
+	if err != nil {
+		return errors.Wrap(err, "marshalling mapUsers")
+	}
+	a.cm.Data[usersData] = string(bs)
+	return nil
+}
+
 // Save persists the ConfigMap to the cluster. It determines
 // whether to create or update by looking at the ConfigMap's UID.
 func (a *AuthConfigMap) Save() (err error) {
@@ -213,6 +296,19 @@ func ObjectMeta() metav1.ObjectMeta {
 	}
 }
 
+// RoleARNWithoutPath strips the path from a role ARN; the authenticator compares mapped
+// roles with ARNs of assumed roles, which never include the path, so a role that has a
+// path can only be mapped without it
+func RoleARNWithoutPath(roleARN string) string {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil || !strings.HasPrefix(parsed.Resource, "role/") {
+		return roleARN
+	}
+	parts := strings.Split(parsed.Resource, "/")
+	parsed.Resource = "role/" + parts[len(parts)-1]
+	return parsed.String()
+}
+
 // AddNodeGroup creates or adds a nodegroup IAM role in the auth
 // ConfigMap for the given nodegroup.
 func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
@@ -220,7 +316,33 @@ func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	if err != nil {
 		return err
 	}
-	if err := acm.AddRole(ng.IAM.InstanceRoleARN, RoleNodeGroupUsername, RoleNodeGroupGroups); err != nil {
+	if err := acm.AddRole(RoleARNWithoutPath(ng.IAM.InstanceRoleARN), RoleNodeGroupUsername, RoleNodeGroupGroups); err != nil {
+		return errors.Wrap(err, "adding nodegroup to auth ConfigMap")
+	}
+	if err := acm.Save(); err != nil {
+		return errors.Wrap(err, "saving auth ConfigMap")
+	}
+	logger.Debug("saved auth ConfigMap for %q", ng.Name)
+	return nil
+}
+
+// EnsureNodeGroup is like AddNodeGroup, but it doesn't add the
+// nodegroup IAM role when it's already in the auth ConfigMap
+func EnsureNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
+	acm, err := NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	roleARN := RoleARNWithoutPath(ng.IAM.InstanceRoleARN)
+	exists, err := acm.HasRole(roleARN)
+	if err != nil {
+		return err
+	}
+	if exists {
+		logger.Debug("role %q of nodegroup %q is already in auth ConfigMap", roleARN, ng.Name)
+		return nil
+	}
+	if err := acm.AddRole(roleARN, RoleNodeGroupUsername, RoleNodeGroupGroups); err != nil {
 		return errors.Wrap(err, "adding nodegroup to auth ConfigMap")
 	}
 	if err := acm.Save(); err != nil {
@@ -237,7 +359,7 @@ func RemoveNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	if err != nil {
 		return err
 	}
-	if err := acm.RemoveRole(ng.IAM.InstanceRoleARN); err != nil {
+	if err := acm.RemoveRole(RoleARNWithoutPath(ng.IAM.InstanceRoleARN)); err != nil {
 		return errors.Wrap(err, "removing nodegroup from auth ConfigMap")
 	}
 	if err := acm.Save(); err != nil {
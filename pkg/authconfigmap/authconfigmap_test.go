@@ -10,11 +10,13 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	. "github.com/weaveworks/eksctl/pkg/authconfigmap"
 )
 
 const (
 	roleA    = "arn:aws:iam::122333:role/eksctl-cluster-5a-nodegroup-ng1-p-NodeInstanceRole-NNH3ISP12CX"
+	userA    = "arn:aws:iam::122333:user/alice"
 	roleB    = "arn:aws:iam::122333:role/eksctl-cluster-5a-nodegroup-ng1-p-NodeInstanceRole-ABCDEFGH"
 	groupB   = "foo"
 	accountA = "123"
@@ -154,6 +156,20 @@ var _ = Describe("AuthConfigMap{}", func() {
 			Expect(cm.Data["mapRoles"]).To(MatchYAML(expected))
 		})
 	})
+	Describe("HasRole()", func() {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: ObjectMeta(),
+			Data:       map[string]string{"mapRoles": expectedA},
+		}
+		acm := New(&mockClient{}, existing)
+
+		It("should find an existing role", func() {
+			Expect(acm.HasRole(roleA)).To(BeTrue())
+		})
+		It("should not find a missing role", func() {
+			Expect(acm.HasRole(roleB)).To(BeFalse())
+		})
+	})
 	Describe("RemoveRole()", func() {
 		existing := &corev1.ConfigMap{
 			ObjectMeta: ObjectMeta(),
@@ -257,4 +273,202 @@ var _ = Describe("AuthConfigMap{}", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Describe("NewIdentity()", func() {
+		It("should determine the kind of identity", func() {
+			id, err := NewIdentity(roleA, "admin", []string{GroupMasters})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Kind).To(Equal(IdentityRole))
+
+			id, err = NewIdentity(userA, "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Kind).To(Equal(IdentityUser))
+
+			id, err = NewIdentity("123456789012", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Kind).To(Equal(IdentityAccount))
+		})
+		It("should remove the path from role ARNs", func() {
+			id, err := NewIdentity("arn:aws:iam::122333:role/team/admins", "admin", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.ARN).To(Equal("arn:aws:iam::122333:role/admins"))
+
+			id, err = NewIdentity("arn:aws:iam::122333:user/team/alice", "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.ARN).To(Equal("arn:aws:iam::122333:user/team/alice"))
+		})
+		It("should reject invalid identities", func() {
+			_, err := NewIdentity("123456789012", "admin", nil)
+			Expect(err).To(MatchError(ContainSubstring("cannot be set for account")))
+
+			_, err = NewIdentity("arn:aws:s3:::bucket", "", nil)
+			Expect(err).To(MatchError(ContainSubstring("is not an IAM ARN")))
+
+			_, err = NewIdentity("arn:aws:iam::122333:group/admins", "", nil)
+			Expect(err).To(MatchError(ContainSubstring("neither a role nor a user")))
+
+			_, err = NewIdentity("admins", "", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Describe("AddIdentity()", func() {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: ObjectMeta(),
+			Data:       map[string]string{"mapRoles": expectedA},
+		}
+		existing.UID = "123456"
+		client := &mockClient{}
+		acm := New(client, existing)
+
+		It("should add a user to mapUsers", func() {
+			id, err := NewIdentity(userA, "alice", []string{GroupMasters})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acm.AddIdentity(id)).To(Succeed())
+			Expect(acm.Save()).To(Succeed())
+			Expect(client.updated.Data["mapUsers"]).To(MatchYAML(`- userarn: ` + userA + `
+  username: alice
+  groups:
+  - system:masters
+`))
+			Expect(client.updated.Data["mapRoles"]).To(MatchYAML(expectedA))
+		})
+		It("should add a role without username", func() {
+			id, err := NewIdentity(roleB, "", []string{groupB})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acm.AddIdentity(id)).To(Succeed())
+			Expect(acm.Save()).To(Succeed())
+			Expect(client.updated.Data["mapRoles"]).To(MatchYAML(expectedA + `- rolearn: ` + roleB + `
+  groups:
+  - foo
+`))
+		})
+		It("should refuse duplicates", func() {
+			account, err := NewIdentity("123456789012", "", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acm.AddIdentity(account)).To(Succeed())
+
+			for _, arn := range []string{roleA, userA, "123456789012"} {
+				id, err := NewIdentity(arn, "", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acm.AddIdentity(id)).To(MatchError(ContainSubstring("is already mapped")))
+			}
+		})
+		It("should list all identities", func() {
+			identities, err := acm.Identities()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identities).To(Equal([]*Identity{
+				{Kind: IdentityRole, ARN: roleA, Username: RoleNodeGroupUsername, Groups: RoleNodeGroupGroups},
+				{Kind: IdentityRole, ARN: roleB, Groups: []string{groupB}},
+				{Kind: IdentityUser, ARN: userA, Username: "alice", Groups: []string{GroupMasters}},
+				{Kind: IdentityAccount, ARN: "123456789012"},
+			}))
+		})
+	})
+	Describe("RemoveIdentity()", func() {
+		It("should remove one or all mappings of an ARN", func() {
+			existing := &corev1.ConfigMap{
+				ObjectMeta: ObjectMeta(),
+				Data:       map[string]string{"mapRoles": expectedA + expectedB + expectedA + expectedA},
+			}
+			acm := New(&mockClient{}, existing)
+			id := &Identity{Kind: IdentityRole, ARN: roleA}
+
+			Expect(acm.RemoveIdentity(id, false)).To(Succeed())
+			Expect(existing.Data["mapRoles"]).To(MatchYAML(expectedB + expectedA + expectedA))
+
+			Expect(acm.RemoveIdentity(id, true)).To(Succeed())
+			Expect(existing.Data["mapRoles"]).To(MatchYAML(expectedB))
+
+			Expect(acm.RemoveIdentity(id, true)).To(MatchError(ContainSubstring("not found")))
+		})
+	})
+	Describe("AddUser(), HasUser() and RemoveUser()", func() {
+		existing := &corev1.ConfigMap{
+			ObjectMeta: ObjectMeta(),
+			Data:       map[string]string{},
+		}
+		acm := New(&mockClient{}, existing)
+		expectedUser := `- userarn: ` + userA + `
+  username: alice
+  groups:
+  - foo
+`
+
+		It("should add users with the same semantics as roles", func() {
+			Expect(acm.AddUser(userA, "alice", []string{groupB})).To(Succeed())
+			Expect(acm.AddUser(userA, "alice", []string{groupB})).To(Succeed())
+			Expect(existing.Data["mapUsers"]).To(MatchYAML(expectedUser + expectedUser))
+			Expect(acm.HasUser(userA)).To(BeTrue())
+			Expect(acm.HasRole(userA)).To(BeFalse())
+		})
+		It("should remove one user for duplicates", func() {
+			Expect(acm.RemoveUser(userA)).To(Succeed())
+			Expect(existing.Data["mapUsers"]).To(MatchYAML(expectedUser))
+			Expect(acm.RemoveUser(userA)).To(Succeed())
+			Expect(acm.HasUser(userA)).To(BeFalse())
+			Expect(acm.RemoveUser(userA)).To(HaveOccurred())
+		})
+	})
+	Describe("SyncIdentities()", func() {
+		It("should add and update declared identities and report undeclared ones", func() {
+			existing := &corev1.ConfigMap{
+				ObjectMeta: ObjectMeta(),
+				Data: map[string]string{
+					"mapRoles": expectedA + `- rolearn: arn:aws:iam::122333:role/admins
+  username: admin
+  groups:
+  - viewers
+- rolearn: arn:aws:iam::122333:role/legacy
+  username: legacy
+`,
+					"mapUsers": `- userarn: ` + userA + `
+  username: alice
+  groups:
+  - foo
+  - bar
+`,
+				},
+			}
+			acm := New(&mockClient{}, existing)
+
+			declared, err := IdentitiesFromConfig([]*api.IAMIdentityMapping{
+				{ARN: "arn:aws:iam::122333:role/admins", Username: "admin", Groups: []string{GroupMasters}},
+				{ARN: userA, Username: "alice", Groups: []string{"bar", "foo"}},
+				{ARN: roleA, Username: "someone"},
+				{ARN: "123456789012"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := acm.SyncIdentities(declared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasChanges()).To(BeTrue())
+			Expect(result.Added).To(ConsistOf(declared[3]))
+			Expect(result.Updated).To(ConsistOf(declared[0]))
+			Expect(result.Undeclared).To(ConsistOf(&Identity{Kind: IdentityRole, ARN: "arn:aws:iam::122333:role/legacy", Username: "legacy"}))
+
+			// nodegroup role is left untouched, legacy role is not removed
+			Expect(existing.Data["mapRoles"]).To(MatchYAML(expectedA + `- rolearn: arn:aws:iam::122333:role/legacy
+  username: legacy
+- rolearn: arn:aws:iam::122333:role/admins
+  username: admin
+  groups:
+  - system:masters
+`))
+			Expect(existing.Data["mapAccounts"]).To(MatchYAML(makeExpectedAccounts("123456789012")))
+
+			result, err = acm.SyncIdentities(declared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasChanges()).To(BeFalse())
+		})
+	})
+
+	Describe("RoleARNWithoutPath()", func() {
+		It("should remove the path of a role", func() {
+			Expect(RoleARNWithoutPath("arn:aws:iam::122333:role/eks/nodes/ng-role")).To(Equal("arn:aws:iam::122333:role/ng-role"))
+		})
+		It("should leave roles without a path, users and invalid ARNs untouched", func() {
+			Expect(RoleARNWithoutPath("arn:aws:iam::122333:role/ng-role")).To(Equal("arn:aws:iam::122333:role/ng-role"))
+			Expect(RoleARNWithoutPath("arn:aws:iam::122333:user/eks/alice")).To(Equal("arn:aws:iam::122333:user/eks/alice"))
+			Expect(RoleARNWithoutPath("122333")).To(Equal("122333"))
+		})
+	})
 })
@@ -0,0 +1,312 @@
+package authconfigmap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Kinds of IAM identities that can be mapped in the auth ConfigMap
+const (
+	IdentityRole    = "role"
+	IdentityUser    = "user"
+	IdentityAccount = "account"
+)
+
+var accountIDPattern = regexp.MustCompile(`^[0-9]{12}$`)
+
+// Identity is an entry of the auth ConfigMap, it maps an IAM role or user to
+// a Kubernetes username and groups, or allows all users of an IAM account
+type Identity struct {
+	Kind string `json:"kind"`
+	// ARN is the ARN of a role or user, or the ID of an account
+	ARN      string   `json:"arn"`
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// NewIdentity determines the kind of the identity from the ARN, which can be
+// the ARN of a role or a user, or an account ID; the path of a role is removed,
+// as the authenticator only matches role ARNs without it
+func NewIdentity(iamARN, username string, groups []string) (*Identity, error) {
+	id := &Identity{ARN: iamARN, Username: username, Groups: groups}
+
+	if accountIDPattern.MatchString(iamARN) {
+		if username != "" || len(groups) > 0 {
+			return nil, fmt.Errorf("username and groups cannot be set for account %q, users of mapped accounts get the username and groups of their IAM ARN", iamARN)
+		}
+		id.Kind = IdentityAccount
+		return id, nil
+	}
+
+	parsed, err := arn.Parse(iamARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%q is neither an ARN nor an account ID", iamARN)
+	}
+	if parsed.Service != "iam" {
+		return nil, fmt.Errorf("%q is not an IAM ARN", iamARN)
+	}
+	switch {
+	case strings.HasPrefix(parsed.Resource, "role/"):
+		id.Kind = IdentityRole
+		if withoutPath := RoleARNWithoutPath(iamARN); withoutPath != iamARN {
+			logger.Info("removing path from role ARN %q, as ARNs of assumed roles don't include it, %q will be mapped", iamARN, withoutPath)
+			id.ARN = withoutPath
+		}
+	case strings.HasPrefix(parsed.Resource, "user/"):
+		id.Kind = IdentityUser
+	default:
+		return nil, fmt.Errorf("%q is neither a role nor a user ARN", iamARN)
+	}
+	return id, nil
+}
+
+func (id *Identity) arnKey() string {
+	if id.Kind == IdentityUser {
+		return "userarn"
+	}
+	return "rolearn"
+}
+
+func (a *AuthConfigMap) entries(id *Identity) (mapRoles, error) {
+	if id.Kind == IdentityUser {
+		return a.users()
+	}
+	return a.roles()
+}
+
+func (a *AuthConfigMap) setEntries(id *Identity, entries mapRoles) error {
+	if id.Kind == IdentityUser {
+		return a.setUsers(entries)
+	}
+	return a.setRoles(entries)
+}
+
+// Identities returns all roles, users and accounts in the auth ConfigMap
+func (a *AuthConfigMap) Identities() ([]*Identity, error) {
+	identities := []*Identity{}
+
+	for _, kind := range []string{IdentityRole, IdentityUser} {
+		id := &Identity{Kind: kind}
+		entries, err := a.entries(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			identities = append(identities, entryToIdentity(kind, id.arnKey(), entry))
+		}
+	}
+
+	accounts, err := a.accounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		identities = append(identities, &Identity{Kind: IdentityAccount, ARN: account})
+	}
+
+	return identities, nil
+}
+
+func entryToIdentity(kind, arnKey string, entry mapRole) *Identity {
+	id := &Identity{Kind: kind}
+	id.ARN, _ = entry[arnKey].(string)
+	id.Username, _ = entry["username"].(string)
+	if groups, ok := entry["groups"].([]interface{}); ok {
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				id.Groups = append(id.Groups, g)
+			}
+		}
+	}
+	return id
+}
+
+// AddIdentity adds a role, user or account to the auth ConfigMap, unlike AddRole
+// it refuses to add an ARN that is already mapped
+func (a *AuthConfigMap) AddIdentity(id *Identity) error {
+	if id.Kind == IdentityAccount {
+		accounts, err := a.accounts()
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if account == id.ARN {
+				return fmt.Errorf("account %q is already mapped in auth ConfigMap", id.ARN)
+			}
+		}
+		return a.AddAccount(id.ARN)
+	}
+
+	entries, err := a.entries(id)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry[id.arnKey()] == id.ARN {
+			return fmt.Errorf("%s %q is already mapped in auth ConfigMap", id.Kind, id.ARN)
+		}
+	}
+	entry := mapRole{id.arnKey(): id.ARN}
+	if id.Username != "" {
+		entry["username"] = id.Username
+	}
+	if len(id.Groups) > 0 {
+		entry["groups"] = id.Groups
+	}
+	logger.Info("adding %s %q to auth ConfigMap", id.Kind, id.ARN)
+	return a.setEntries(id, append(entries, entry))
+}
+
+// RemoveIdentity removes a role, user or account from the auth ConfigMap; when the
+// ARN is mapped more than once, only the first entry is removed, unless all is set
+func (a *AuthConfigMap) RemoveIdentity(id *Identity, all bool) error {
+	if id.Kind == IdentityAccount {
+		return a.RemoveAccount(id.ARN)
+	}
+
+	entries, err := a.entries(id)
+	if err != nil {
+		return err
+	}
+	remaining := mapRoles{}
+	removed := 0
+	for _, entry := range entries {
+		if entry[id.arnKey()] == id.ARN && (all || removed == 0) {
+			removed++
+			continue
+		}
+		remaining = append(remaining, entry)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%s %q not found in auth ConfigMap", id.Kind, id.ARN)
+	}
+	logger.Info("removing %d mapping(s) of %s %q from auth ConfigMap", removed, id.Kind, id.ARN)
+	return a.setEntries(id, remaining)
+}
+
+// IdentitiesFromConfig converts the mappings declared in ClusterConfig
+func IdentitiesFromConfig(mappings []*api.IAMIdentityMapping) ([]*Identity, error) {
+	identities := []*Identity{}
+	for i, m := range mappings {
+		id, err := NewIdentity(m.ARN, m.Username, m.Groups)
+		if err != nil {
+			return nil, errors.Wrapf(err, "iamIdentityMappings[%d]", i)
+		}
+		identities = append(identities, id)
+	}
+	return identities, nil
+}
+
+// IsNodeGroupIdentity returns true for role mappings that eksctl manages for nodegroups
+func IsNodeGroupIdentity(id *Identity) bool {
+	return id.Kind == IdentityRole && id.Username == RoleNodeGroupUsername
+}
+
+// SyncResult describes the changes made by SyncIdentities
+type SyncResult struct {
+	Added      []*Identity
+	Updated    []*Identity
+	Undeclared []*Identity
+}
+
+// HasChanges returns true when any identities were added or updated
+func (r *SyncResult) HasChanges() bool {
+	return len(r.Added)+len(r.Updated) > 0
+}
+
+// SyncIdentities makes sure that each of the declared identities is mapped exactly once with
+// the declared username and groups; mappings of nodegroup roles are never touched, and other
+// mappings that are not declared are not removed, but these are returned as undeclared
+func (a *AuthConfigMap) SyncIdentities(declared []*Identity) (*SyncResult, error) {
+	current, err := a.Identities()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	isDeclared := map[string]bool{}
+	for _, id := range declared {
+		isDeclared[id.ARN] = true
+
+		existing := []*Identity{}
+		skip := false
+		for _, c := range current {
+			if c.Kind != id.Kind || c.ARN != id.ARN {
+				continue
+			}
+			if IsNodeGroupIdentity(c) {
+				logger.Warning("%s %q is mapped for a nodegroup, its mapping will not be changed", id.Kind, id.ARN)
+				skip = true
+				break
+			}
+			existing = append(existing, c)
+		}
+		switch {
+		case skip:
+			continue
+		case len(existing) == 0:
+			if err := a.AddIdentity(id); err != nil {
+				return nil, err
+			}
+			result.Added = append(result.Added, id)
+		case len(existing) == 1 && sameMapping(existing[0], id):
+			continue
+		default:
+			if err := a.RemoveIdentity(id, true); err != nil {
+				return nil, err
+			}
+			if err := a.AddIdentity(id); err != nil {
+				return nil, err
+			}
+			result.Updated = append(result.Updated, id)
+		}
+	}
+
+	for _, c := range current {
+		if !isDeclared[c.ARN] && !IsNodeGroupIdentity(c) {
+			result.Undeclared = append(result.Undeclared, c)
+		}
+	}
+	return result, nil
+}
+
+func sameMapping(a, b *Identity) bool {
+	return a.Username == b.Username && sets.NewString(a.Groups...).Equal(sets.NewString(b.Groups...))
+}
+
+// SyncIAMIdentityMappings syncs the mappings declared in ClusterConfig into the auth
+// ConfigMap, and reports mappings that are not declared; nothing is saved in plan mode
+func SyncIAMIdentityMappings(clientSet kubernetes.Interface, mappings []*api.IAMIdentityMapping, plan bool) (*SyncResult, error) {
+	declared, err := IdentitiesFromConfig(mappings)
+	if err != nil {
+		return nil, err
+	}
+	acm, err := NewFromClientSet(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	result, err := acm.SyncIdentities(declared)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range result.Undeclared {
+		logger.Warning("%s %q is mapped in auth ConfigMap, but it's not declared in iamIdentityMappings", id.Kind, id.ARN)
+	}
+	if plan || !result.HasChanges() {
+		return result, nil
+	}
+	if err := acm.Save(); err != nil {
+		return nil, errors.Wrap(err, "saving auth ConfigMap")
+	}
+	logger.Debug("saved auth ConfigMap with %d added and %d updated mapping(s)", len(result.Added), len(result.Updated))
+	return result, nil
+}
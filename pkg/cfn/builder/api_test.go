@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/tidwall/gjson"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
@@ -51,6 +52,7 @@ type Properties struct {
 	Tags []Tag
 
 	Path, RoleName           string
+	PermissionsBoundary      string
 	Roles, ManagedPolicyArns []interface{}
 	AssumeRolePolicyDocument interface{}
 
@@ -364,6 +366,9 @@ var _ = Describe("CloudFormation template builder API", func() {
 							FSX:          api.Disabled(),
 							EFS:          api.Disabled(),
 							ALBIngress:   api.Disabled(),
+							CloudWatch:   api.Disabled(),
+							XRay:         api.Disabled(),
+							CertManager:  api.Disabled(),
 						},
 					},
 					SSH: &api.NodeGroupSSH{
@@ -527,6 +532,12 @@ var _ = Describe("CloudFormation template builder API", func() {
 			Expect(getNodeGroupProperties(obj).MinSize).To(Equal("2"))
 
 		})
+
+		It("should output the min size as desired capacity", func() {
+			templateBody, err := ngrs.RenderJSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(gjson.GetBytes(templateBody, "Outputs.DesiredCapacity.Value").String()).To(Equal("2"))
+		})
 	})
 
 	Context("NodeGroup DesiredCapacity=10 MaxSize=nil MinSize=nil", func() {
@@ -664,6 +675,57 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("NodeGroup with extra resources and outputs", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.CloudFormation = &api.CloudFormationExtras{
+			ExtraResources: map[string]json.RawMessage{
+				"SpotInterruptionQueue": json.RawMessage(`{"Type": "AWS::SQS::Queue", "Properties": {"MessageRetentionPeriod": 300}}`),
+				"SpotInterruptionQueuePolicy": json.RawMessage(`{"Type": "AWS::IAM::Policy", "Properties": {
+					"PolicyName": "spot-interruption-queue",
+					"Roles": [{"Ref": "NodeInstanceRole"}],
+					"PolicyDocument": {"Statement": [{"Effect": "Allow", "Action": ["sqs:ReceiveMessage"], "Resource": {"Fn::GetAtt": ["SpotInterruptionQueue", "Arn"]}}]}
+				}}`),
+			},
+			ExtraOutputs: map[string]json.RawMessage{
+				"SpotInterruptionQueueURL": json.RawMessage(`{"Value": {"Ref": "SpotInterruptionQueue"}}`),
+			},
+		}
+
+		build(cfg, "eksctl-test-extras-cluster", ng)
+
+		roundtript()
+
+		It("should have extra resources and outputs", func() {
+			Expect(obj.Resources).To(HaveKey("NodeGroup"))
+			Expect(obj.Resources).To(HaveKey("SpotInterruptionQueue"))
+			Expect(obj.Resources["SpotInterruptionQueuePolicy"].Properties.PolicyDocument.Statement[0].Action).To(Equal([]string{"sqs:ReceiveMessage"}))
+			Expect(ngrs.Template().Outputs).To(HaveKey("SpotInterruptionQueueURL"))
+		})
+
+		It("should fail when extra resources refer to undefined resources", func() {
+			_, ng := newClusterConfigAndNodegroup(true)
+			ng.CloudFormation = &api.CloudFormationExtras{
+				ExtraOutputs: map[string]json.RawMessage{
+					"QueueURL": json.RawMessage(`{"Value": {"Ref": "MissingQueue"}}`),
+				},
+			}
+			err := NewNodeGroupResourceSet(p, cfg, "eksctl-test-extras-cluster", ng).AddAllResources()
+			Expect(err).To(MatchError(`extra output "QueueURL": refers to "MissingQueue", which is not defined in the stack`))
+		})
+
+		It("should fail when extra resources replace resources defined by eksctl", func() {
+			_, ng := newClusterConfigAndNodegroup(true)
+			ng.CloudFormation = &api.CloudFormationExtras{
+				ExtraResources: map[string]json.RawMessage{
+					"NodeInstanceRole": json.RawMessage(`{"Type": "AWS::IAM::Role"}`),
+				},
+			}
+			err := NewNodeGroupResourceSet(p, cfg, "eksctl-test-extras-cluster", ng).AddAllResources()
+			Expect(err).To(MatchError(`extra resource "NodeInstanceRole" cannot be added, as eksctl defines resource with the same logical ID`))
+		})
+	})
+
 	Context("NodeGroupAutoScaling", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -946,6 +1008,141 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 	})
 
+	Context("NodeGroup with an inline policy", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.AttachPolicy = []byte(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Action": ["s3:GetObject"],
+				"Resource": "arn:aws:s3:::bucket-1/*"
+			}]
+		}`)
+
+		build(cfg, "eksctl-test-inline-policy-cluster", ng)
+
+		roundtript()
+
+		It("should attach the policy to the instance role", func() {
+			Expect(obj.Resources).To(HaveKey("PolicyInline"))
+
+			policy := obj.Resources["PolicyInline"].Properties
+
+			Expect(policy.Roles).To(HaveLen(1))
+			isRefTo(policy.Roles[0], "NodeInstanceRole")
+
+			Expect(policy.PolicyDocument.Statement).To(HaveLen(1))
+			Expect(policy.PolicyDocument.Statement[0].Effect).To(Equal("Allow"))
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws:s3:::bucket-1/*"))
+			Expect(policy.PolicyDocument.Statement[0].Action).To(Equal([]string{"s3:GetObject"}))
+		})
+	})
+
+	Context("NodeGroupCloudWatchAndXRay", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.WithAddonPolicies.CloudWatch = api.Enabled()
+		ng.IAM.WithAddonPolicies.XRay = api.Enabled()
+
+		build(cfg, "eksctl-test-monitoring-cluster", ng)
+
+		roundtript()
+
+		It("should have correct managed policies", func() {
+			role := obj.Resources["NodeInstanceRole"].Properties
+
+			Expect(role.ManagedPolicyArns).To(HaveLen(5))
+			Expect(role.ManagedPolicyArns[3]).To(Equal("arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"))
+			Expect(role.ManagedPolicyArns[4]).To(Equal("arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess"))
+		})
+	})
+
+	Context("NodeGroupCertManager", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.WithAddonPolicies.CertManager = api.Enabled()
+
+		build(cfg, "eksctl-test-cert-manager-cluster", ng)
+
+		roundtript()
+
+		It("should have correct policies", func() {
+			Expect(obj.Resources).To(HaveKey("PolicyCertManagerChangeSet"))
+			Expect(obj.Resources).To(HaveKey("PolicyCertManagerGetChange"))
+			Expect(obj.Resources).To(HaveKey("PolicyCertManagerHostedZones"))
+
+			policy := obj.Resources["PolicyCertManagerChangeSet"].Properties
+
+			Expect(policy.Roles).To(HaveLen(1))
+			isRefTo(policy.Roles[0], "NodeInstanceRole")
+
+			Expect(policy.PolicyDocument.Statement).To(HaveLen(1))
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws:route53:::hostedzone/*"))
+			Expect(policy.PolicyDocument.Statement[0].Action).To(Equal([]string{
+				"route53:ChangeResourceRecordSets",
+				"route53:ListResourceRecordSets",
+			}))
+
+			policy = obj.Resources["PolicyCertManagerGetChange"].Properties
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws:route53:::change/*"))
+			Expect(policy.PolicyDocument.Statement[0].Action).To(Equal([]string{"route53:GetChange"}))
+
+			policy = obj.Resources["PolicyCertManagerHostedZones"].Properties
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("*"))
+			Expect(policy.PolicyDocument.Statement[0].Action).To(Equal([]string{"route53:ListHostedZonesByName"}))
+		})
+	})
+
+	Context("NodeGroup in China", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Region = "cn-north-1"
+		ng.IAM.WithAddonPolicies.EFS = api.Enabled()
+		ng.IAM.WithAddonPolicies.ExternalDNS = api.Enabled()
+
+		build(cfg, "eksctl-test-china-cluster", ng)
+
+		roundtript()
+
+		It("should use ARNs and service principals of the partition", func() {
+			clusterObj := &Template{}
+			templateBody, err := crs.RenderJSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(json.Unmarshal(templateBody, clusterObj)).To(Succeed())
+
+			serviceRole := clusterObj.Resources["ServiceRole"].Properties
+			Expect(serviceRole.ManagedPolicyArns).To(Equal([]interface{}{
+				"arn:aws-cn:iam::aws:policy/AmazonEKSServicePolicy",
+				"arn:aws-cn:iam::aws:policy/AmazonEKSClusterPolicy",
+			}))
+
+			role := obj.Resources["NodeInstanceRole"].Properties
+			Expect(role.ManagedPolicyArns).To(Equal([]interface{}{
+				"arn:aws-cn:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws-cn:iam::aws:policy/AmazonEKS_CNI_Policy",
+				"arn:aws-cn:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+			}))
+			actualARPD, _ := json.Marshal(role.AssumeRolePolicyDocument)
+			Expect(actualARPD).To(MatchJSON(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Action": ["sts:AssumeRole"],
+					"Effect": "Allow",
+					"Principal": {"Service": ["ec2.amazonaws.com.cn"]}
+				}]
+			}`))
+
+			policy := obj.Resources["PolicyEFS"].Properties
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal(map[string]interface{}{
+				"Fn::Sub": "arn:aws-cn:elasticfilesystem:cn-north-1:${AWS::AccountId}:file-system/*",
+			}))
+
+			policy = obj.Resources["PolicyExternalDNSChangeSet"].Properties
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws-cn:route53:::hostedzone/*"))
+		})
+	})
+
 	Context("NodeGroupEBS", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -1047,7 +1244,9 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 			Expect(policy.PolicyDocument.Statement).To(HaveLen(1))
 			Expect(policy.PolicyDocument.Statement[0].Effect).To(Equal("Allow"))
-			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/*"))
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal(map[string]interface{}{
+				"Fn::Sub": "arn:aws:elasticfilesystem:us-west-2:${AWS::AccountId}:file-system/*",
+			}))
 			Expect(policy.PolicyDocument.Statement[0].Action).To(Equal([]string{
 				"elasticfilesystem:*",
 			}))
@@ -1101,6 +1300,63 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("NodeGroup with cluster-wide IAM path and permissions boundary", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.IAM.ServiceRoleName = "eks-service-role"
+		cfg.IAM.Path = "/eks/"
+		cfg.IAM.PermissionsBoundary = "arn:aws:iam::123456789012:policy/boundary"
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtript()
+
+		It("should set path, name and permissions boundary of the service role", func() {
+			clusterObj := &Template{}
+			templateBody, err := crs.RenderJSON()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(json.Unmarshal(templateBody, clusterObj)).To(Succeed())
+
+			Expect(crs.WithNamedIAM()).To(BeTrue())
+			Expect(clusterObj.Resources).To(HaveKey("ServiceRole"))
+			role := clusterObj.Resources["ServiceRole"].Properties
+			Expect(role.Path).To(Equal("/eks/"))
+			Expect(role.RoleName).To(Equal("eks-service-role"))
+			Expect(role.PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/boundary"))
+		})
+
+		It("should inherit path and permissions boundary for the instance role and profile", func() {
+			role := obj.Resources["NodeInstanceRole"].Properties
+			Expect(role.Path).To(Equal("/eks/"))
+			Expect(role.PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/boundary"))
+
+			profile := obj.Resources["NodeInstanceProfile"].Properties
+			Expect(profile.Path).To(Equal("/eks/"))
+		})
+	})
+
+	Context("NodeGroup with its own IAM path and permissions boundary", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.IAM.Path = "/eks/"
+		cfg.IAM.PermissionsBoundary = "arn:aws:iam::123456789012:policy/boundary"
+		ng.IAM.Path = "/eks/nodes/"
+		ng.IAM.PermissionsBoundary = "arn:aws:iam::123456789012:policy/nodes-boundary"
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtript()
+
+		It("should use path and permissions boundary of the nodegroup", func() {
+			role := obj.Resources["NodeInstanceRole"].Properties
+			Expect(role.Path).To(Equal("/eks/nodes/"))
+			Expect(role.PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/nodes-boundary"))
+
+			profile := obj.Resources["NodeInstanceProfile"].Properties
+			Expect(profile.Path).To(Equal("/eks/nodes/"))
+		})
+	})
+
 	Context("NodeGroup with cutom profile", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -48,6 +48,7 @@ func (c *ClusterResourceSet) AddAllResources() error {
 
 	c.addResourcesForSecurityGroups()
 	c.addResourcesForIAM()
+	c.addResourcesForFlowLogs()
 	c.addResourcesForControlPlane()
 
 	c.rs.defineOutput(outputs.ClusterStackName, gfn.RefStackName, false, func(v string) error {
@@ -64,7 +65,7 @@ func (c *ClusterResourceSet) AddAllResources() error {
 		dedicatedVPC, c.rs.withIAM,
 		templateDescriptionSuffix)
 
-	return nil
+	return c.rs.addExtras(c.spec.CloudFormation)
 }
 
 // RenderJSON returns the rendered JSON
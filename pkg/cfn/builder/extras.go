@@ -0,0 +1,132 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// addExtras merges extra resources and outputs from the config into the template,
+// these may refer to any of the resources or parameters defined by eksctl, but
+// cannot replace any of them
+func (r *resourceSet) addExtras(extras *api.CloudFormationExtras) error {
+	if extras == nil {
+		return nil
+	}
+
+	resources, err := parseExtras(extras.ExtraResources, r.template.Resources, "resource")
+	if err != nil {
+		return err
+	}
+	outputs, err := parseExtras(extras.ExtraOutputs, r.template.Outputs, "output")
+	if err != nil {
+		return err
+	}
+
+	for id, resource := range resources {
+		r.template.Resources[id] = resource
+	}
+	for id, output := range outputs {
+		r.template.Outputs[id] = output
+	}
+
+	if err := r.checkAllReferences(resources, "resource"); err != nil {
+		return err
+	}
+	return r.checkAllReferences(outputs, "output")
+}
+
+func parseExtras(snippets map[string]json.RawMessage, existing map[string]interface{}, kind string) (map[string]interface{}, error) {
+	parsed := map[string]interface{}{}
+	for id, snippet := range snippets {
+		if _, ok := existing[id]; ok {
+			return nil, fmt.Errorf("extra %s %q cannot be added, as eksctl defines %s with the same logical ID", kind, id, kind)
+		}
+		var value map[string]interface{}
+		if err := json.Unmarshal(snippet, &value); err != nil {
+			return nil, errors.Wrapf(err, "parsing extra %s %q", kind, id)
+		}
+		parsed[id] = value
+	}
+	return parsed, nil
+}
+
+func (r *resourceSet) checkAllReferences(snippets map[string]interface{}, kind string) error {
+	ids := []string{}
+	for id := range snippets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := r.checkReferences(snippets[id]); err != nil {
+			return errors.Wrapf(err, "extra %s %q", kind, id)
+		}
+	}
+	return nil
+}
+
+// checkReferences walks through a snippet and checks that Ref, Fn::GetAtt
+// and DependsOn only refer to resources or parameters in the template
+func (r *resourceSet) checkReferences(snippet interface{}) error {
+	switch v := snippet.(type) {
+	case map[string]interface{}:
+		for k, x := range v {
+			var err error
+			switch k {
+			case "Ref":
+				err = r.checkReference(x, true)
+			case "Fn::GetAtt":
+				if attr, ok := x.(string); ok {
+					x = strings.Split(attr, ".")[0]
+				} else if attr, ok := x.([]interface{}); ok && len(attr) > 0 {
+					x = attr[0]
+				}
+				err = r.checkReference(x, false)
+			case "DependsOn":
+				if ids, ok := x.([]interface{}); ok {
+					for _, id := range ids {
+						if err = r.checkReference(id, false); err != nil {
+							break
+						}
+					}
+				} else {
+					err = r.checkReference(x, false)
+				}
+			default:
+				err = r.checkReferences(x)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, x := range v {
+			if err := r.checkReferences(x); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *resourceSet) checkReference(ref interface{}, allowParameters bool) error {
+	id, ok := ref.(string)
+	if !ok {
+		// intrinsic functions may be used for attribute names, CloudFormation will check these
+		return r.checkReferences(ref)
+	}
+	if _, ok := r.template.Resources[id]; ok {
+		return nil
+	}
+	if allowParameters {
+		if _, ok := r.template.Parameters[id]; ok || strings.HasPrefix(id, "AWS::") {
+			return nil
+		}
+	}
+	return fmt.Errorf("refers to %q, which is not defined in the stack", id)
+}
@@ -1,7 +1,11 @@
 package builder
 
 import (
+	"encoding/json"
+	"fmt"
+
 	gfn "github.com/awslabs/goformation/cloudformation"
+	"github.com/pkg/errors"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
@@ -9,22 +13,33 @@ import (
 )
 
 const (
-	iamPolicyAmazonEKSServicePolicyARN = "arn:aws:iam::aws:policy/AmazonEKSServicePolicy"
-	iamPolicyAmazonEKSClusterPolicyARN = "arn:aws:iam::aws:policy/AmazonEKSClusterPolicy"
-
-	iamPolicyAmazonEKSWorkerNodePolicyARN           = "arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy"
-	iamPolicyAmazonEKSCNIPolicyARN                  = "arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy"
-	iamPolicyAmazonEC2ContainerRegistryPowerUserARN = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryPowerUser"
-	iamPolicyAmazonEC2ContainerRegistryReadOnlyARN  = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly"
+	iamPolicyAmazonEKSServicePolicy = "AmazonEKSServicePolicy"
+	iamPolicyAmazonEKSClusterPolicy = "AmazonEKSClusterPolicy"
+
+	iamPolicyAmazonEKSWorkerNodePolicy           = "AmazonEKSWorkerNodePolicy"
+	iamPolicyAmazonEKSCNIPolicy                  = "AmazonEKS_CNI_Policy"
+	iamPolicyAmazonEC2ContainerRegistryPowerUser = "AmazonEC2ContainerRegistryPowerUser"
+	iamPolicyAmazonEC2ContainerRegistryReadOnly  = "AmazonEC2ContainerRegistryReadOnly"
+	iamPolicyCloudWatchAgentServerPolicy         = "CloudWatchAgentServerPolicy"
+	iamPolicyAWSXRayDaemonWriteAccess            = "AWSXRayDaemonWriteAccess"
 )
 
 var (
-	iamDefaultNodePolicyARNs = []string{
-		iamPolicyAmazonEKSWorkerNodePolicyARN,
-		iamPolicyAmazonEKSCNIPolicyARN,
+	iamDefaultNodePolicies = []string{
+		iamPolicyAmazonEKSWorkerNodePolicy,
+		iamPolicyAmazonEKSCNIPolicy,
 	}
 )
 
+// makePolicyARNs returns ARNs of AWS managed policies in the partition of the region
+func makePolicyARNs(region string, policyNames ...string) []string {
+	arns := []string{}
+	for _, policyName := range policyNames {
+		arns = append(arns, api.PartitionARN(region, "iam::aws:policy/"+policyName))
+	}
+	return arns
+}
+
 func makePolicyDocument(statement map[string]interface{}) map[string]interface{} {
 	return map[string]interface{}{
 		"Version": "2012-10-17",
@@ -44,6 +59,16 @@ func makeAssumeRolePolicyDocument(service string) map[string]interface{} {
 	})
 }
 
+// setRolePathAndBoundary sets path and permissions boundary of a role, unless these are empty
+func setRolePathAndBoundary(role *gfn.AWSIAMRole, path, permissionsBoundary string) {
+	if path != "" {
+		role.Path = gfn.NewString(path)
+	}
+	if permissionsBoundary != "" {
+		role.PermissionsBoundary = gfn.NewString(permissionsBoundary)
+	}
+}
+
 func (c *resourceSet) attachAllowPolicy(name string, refRole *gfn.Value, resources interface{}, actions []string) {
 	c.newResource(name, &gfn.AWSIAMPolicy{
 		PolicyName: makeName(name),
@@ -77,13 +102,23 @@ func (c *ClusterResourceSet) addResourcesForIAM() {
 
 	c.rs.withIAM = true
 
-	refSR := c.newResource("ServiceRole", &gfn.AWSIAMRole{
-		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument("eks.amazonaws.com"),
-		ManagedPolicyArns: makeStringSlice(
-			iamPolicyAmazonEKSServicePolicyARN,
-			iamPolicyAmazonEKSClusterPolicyARN,
-		),
-	})
+	region := c.spec.Metadata.Region
+	role := gfn.AWSIAMRole{
+		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument(api.ServicePrincipal("eks", region)),
+		ManagedPolicyArns: makeStringSlice(makePolicyARNs(region,
+			iamPolicyAmazonEKSServicePolicy,
+			iamPolicyAmazonEKSClusterPolicy,
+		)...),
+	}
+	setRolePathAndBoundary(&role, c.spec.IAM.Path, c.spec.IAM.PermissionsBoundary)
+
+	if c.spec.IAM.ServiceRoleName != "" {
+		// setting role name requires additional capabilities
+		c.rs.withNamedIAM = true
+		role.RoleName = gfn.NewString(c.spec.IAM.ServiceRoleName)
+	}
+
+	refSR := c.newResource("ServiceRole", &role)
 	c.rs.attachAllowPolicy("PolicyNLB", refSR, "*", []string{
 		"elasticloadbalancing:*",
 		"ec2:CreateSecurityGroup",
@@ -108,7 +143,28 @@ func (n *NodeGroupResourceSet) WithNamedIAM() bool {
 	return n.rs.withNamedIAM
 }
 
-func (n *NodeGroupResourceSet) addResourcesForIAM() {
+// iamPath returns the path of the role and instance profile of the nodegroup,
+// which is inherited from the cluster, or "/" if neither is set
+func (n *NodeGroupResourceSet) iamPath() string {
+	if n.spec.IAM.Path != "" {
+		return n.spec.IAM.Path
+	}
+	if n.clusterSpec.IAM.Path != "" {
+		return n.clusterSpec.IAM.Path
+	}
+	return "/"
+}
+
+// iamPermissionsBoundary returns the permissions boundary of the role of the
+// nodegroup, which is inherited from the cluster
+func (n *NodeGroupResourceSet) iamPermissionsBoundary() string {
+	if n.spec.IAM.PermissionsBoundary != "" {
+		return n.spec.IAM.PermissionsBoundary
+	}
+	return n.clusterSpec.IAM.PermissionsBoundary
+}
+
+func (n *NodeGroupResourceSet) addResourcesForIAM() error {
 	if n.spec.IAM == nil {
 		n.spec.IAM = &api.NodeGroupIAM{}
 	}
@@ -123,14 +179,14 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 		if n.spec.IAM.InstanceRoleARN != "" {
 			n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceProfileARN, n.spec.IAM.InstanceProfileARN, true)
 			n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceRoleARN, n.spec.IAM.InstanceRoleARN, true)
-			return
+			return nil
 		}
 		// if instance role is not given, export profile and use the getter to call importer function
 		n.rs.defineOutput(outputs.NodeGroupInstanceProfileARN, n.spec.IAM.InstanceProfileARN, true, func(v string) error {
 			return iam.ImportInstanceRoleFromProfileARN(n.provider, n.spec, v)
 		})
 
-		return
+		return nil
 	}
 
 	n.rs.withIAM = true
@@ -138,7 +194,7 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 	if n.spec.IAM.InstanceRoleARN != "" {
 		// if role is set, but profile isn't - create profile
 		n.newResource("NodeInstanceProfile", &gfn.AWSIAMInstanceProfile{
-			Path:  gfn.NewString("/"),
+			Path:  gfn.NewString(n.iamPath()),
 			Roles: makeStringSlice(n.spec.IAM.InstanceRoleARN),
 		})
 		n.instanceProfileARN = gfn.MakeFnGetAttString("NodeInstanceProfile.Arn")
@@ -147,7 +203,7 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 			return nil
 		})
 		n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceRoleARN, n.spec.IAM.InstanceRoleARN, true)
-		return
+		return nil
 	}
 
 	// if neither role nor profile are given - create both
@@ -157,20 +213,29 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 		n.rs.withNamedIAM = true
 	}
 
+	region := n.clusterSpec.Metadata.Region
+	partition := api.Partition(region)
+
 	if len(n.spec.IAM.AttachPolicyARNs) == 0 {
-		n.spec.IAM.AttachPolicyARNs = iamDefaultNodePolicyARNs
+		n.spec.IAM.AttachPolicyARNs = makePolicyARNs(region, iamDefaultNodePolicies...)
 	}
 	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.ImageBuilder) {
-		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, iamPolicyAmazonEC2ContainerRegistryPowerUserARN)
+		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, makePolicyARNs(region, iamPolicyAmazonEC2ContainerRegistryPowerUser)...)
 	} else {
-		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, iamPolicyAmazonEC2ContainerRegistryReadOnlyARN)
+		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, makePolicyARNs(region, iamPolicyAmazonEC2ContainerRegistryReadOnly)...)
+	}
+	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.CloudWatch) {
+		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, makePolicyARNs(region, iamPolicyCloudWatchAgentServerPolicy)...)
+	}
+	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.XRay) {
+		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, makePolicyARNs(region, iamPolicyAWSXRayDaemonWriteAccess)...)
 	}
 
 	role := gfn.AWSIAMRole{
-		Path:                     gfn.NewString("/"),
-		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument("ec2.amazonaws.com"),
+		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument(api.ServicePrincipal("ec2", region)),
 		ManagedPolicyArns:        makeStringSlice(n.spec.IAM.AttachPolicyARNs...),
 	}
+	setRolePathAndBoundary(&role, n.iamPath(), n.iamPermissionsBoundary())
 
 	if n.spec.IAM.InstanceRoleName != "" {
 		role.RoleName = gfn.NewString(n.spec.IAM.InstanceRoleName)
@@ -179,11 +244,23 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 	refIR := n.newResource("NodeInstanceRole", &role)
 
 	n.newResource("NodeInstanceProfile", &gfn.AWSIAMInstanceProfile{
-		Path:  gfn.NewString("/"),
+		Path:  gfn.NewString(n.iamPath()),
 		Roles: makeSlice(refIR),
 	})
 	n.instanceProfileARN = gfn.MakeFnGetAttString("NodeInstanceProfile.Arn")
 
+	if len(n.spec.IAM.AttachPolicy) != 0 {
+		var document map[string]interface{}
+		if err := json.Unmarshal(n.spec.IAM.AttachPolicy, &document); err != nil {
+			return errors.Wrap(err, "parsing iam.attachPolicy")
+		}
+		n.newResource("PolicyInline", &gfn.AWSIAMPolicy{
+			PolicyName:     makeName("PolicyInline"),
+			Roles:          makeSlice(refIR),
+			PolicyDocument: document,
+		})
+	}
+
 	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.AutoScaler) {
 		n.rs.attachAllowPolicy("PolicyAutoScaling", refIR, "*",
 			[]string{
@@ -198,7 +275,7 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 	}
 
 	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.ExternalDNS) {
-		n.rs.attachAllowPolicy("PolicyExternalDNSChangeSet", refIR, "arn:aws:route53:::hostedzone/*",
+		n.rs.attachAllowPolicy("PolicyExternalDNSChangeSet", refIR, api.PartitionARN(region, "route53:::hostedzone/*"),
 			[]string{
 				"route53:ChangeResourceRecordSets",
 			},
@@ -244,7 +321,7 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 				"fsx:*",
 			},
 		)
-		n.rs.attachAllowPolicy("PolicyServiceLinkRole", refIR, "arn:aws:iam::*:role/aws-service-role/*",
+		n.rs.attachAllowPolicy("PolicyServiceLinkRole", refIR, api.PartitionARN(region, "iam::*:role/aws-service-role/*"),
 			[]string{
 				"iam:CreateServiceLinkedRole",
 				"iam:AttachRolePolicy",
@@ -254,7 +331,8 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 	}
 
 	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.EFS) {
-		n.rs.attachAllowPolicy("PolicyEFS", refIR, "arn:aws:elasticfilesystem:us-west-2:123456789012:file-system/*",
+		n.rs.attachAllowPolicy("PolicyEFS", refIR,
+			gfn.MakeFnSubString(fmt.Sprintf("arn:%s:elasticfilesystem:%s:${%s}:file-system/*", partition, region, gfn.AccountID)),
 			[]string{
 				"elasticfilesystem:*",
 			},
@@ -342,6 +420,25 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 		)
 	}
 
+	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.CertManager) {
+		n.rs.attachAllowPolicy("PolicyCertManagerChangeSet", refIR, api.PartitionARN(region, "route53:::hostedzone/*"),
+			[]string{
+				"route53:ChangeResourceRecordSets",
+				"route53:ListResourceRecordSets",
+			},
+		)
+		n.rs.attachAllowPolicy("PolicyCertManagerGetChange", refIR, api.PartitionARN(region, "route53:::change/*"),
+			[]string{
+				"route53:GetChange",
+			},
+		)
+		n.rs.attachAllowPolicy("PolicyCertManagerHostedZones", refIR, "*",
+			[]string{
+				"route53:ListHostedZonesByName",
+			},
+		)
+	}
+
 	n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceProfileARN, "NodeInstanceProfile.Arn", true, func(v string) error {
 		n.spec.IAM.InstanceProfileARN = v
 		return nil
@@ -350,4 +447,5 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 		n.spec.IAM.InstanceRoleARN = v
 		return nil
 	})
+	return nil
 }
@@ -86,10 +86,16 @@ func (n *NodeGroupResourceSet) AddAllResources() error {
 		return fmt.Errorf("cannot use --nodes-min=%d and --nodes-max=%d at the same time", *n.spec.MinSize, *n.spec.MaxSize)
 	}
 
-	n.addResourcesForIAM()
+	if err := n.addResourcesForIAM(); err != nil {
+		return err
+	}
 	n.addResourcesForSecurityGroups()
 
-	return n.addResourcesForNodeGroup()
+	if err := n.addResourcesForNodeGroup(); err != nil {
+		return err
+	}
+
+	return n.rs.addExtras(n.spec.CloudFormation)
 }
 
 // RenderJSON returns the rendered JSON
@@ -206,13 +212,22 @@ func (n *NodeGroupResourceSet) addResourcesForNodeGroup() error {
 	}
 	if n.spec.DesiredCapacity != nil {
 		ngProps["DesiredCapacity"] = fmt.Sprintf("%d", *n.spec.DesiredCapacity)
+		n.rs.defineOutputWithoutCollector(outputs.NodeGroupDesiredCapacity, ngProps["DesiredCapacity"], false)
+	} else if n.spec.MinSize != nil {
+		// the initial capacity of an ASG is its min size, the output is needed so
+		// that scaling the nodegroup can update it
+		n.rs.defineOutputWithoutCollector(outputs.NodeGroupDesiredCapacity, fmt.Sprintf("%d", *n.spec.MinSize), false)
 	}
 	if n.spec.MinSize != nil {
 		ngProps["MinSize"] = fmt.Sprintf("%d", *n.spec.MinSize)
+		n.rs.defineOutputWithoutCollector(outputs.NodeGroupMinSize, ngProps["MinSize"], false)
 	}
 	if n.spec.MaxSize != nil {
 		ngProps["MaxSize"] = fmt.Sprintf("%d", *n.spec.MaxSize)
+		n.rs.defineOutputWithoutCollector(outputs.NodeGroupMaxSize, ngProps["MaxSize"], false)
 	}
+	n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceType, n.spec.InstanceType, false)
+	n.rs.defineOutputWithoutCollector(outputs.NodeGroupImageID, n.spec.AMI, false)
 	if len(n.spec.TargetGroupARNs) > 0 {
 		ngProps["TargetGroupARNs"] = n.spec.TargetGroupARNs
 	}
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"fmt"
 	"strings"
 
 	gfn "github.com/awslabs/goformation/cloudformation"
@@ -90,6 +91,55 @@ func (c *ClusterResourceSet) addResourcesForVPC() {
 	})
 
 	c.addSubnets(refPrivateRT, api.SubnetTopologyPrivate, c.spec.VPC.Subnets.Private)
+
+	c.addResourcesForAttachments(map[api.SubnetTopology]*gfn.Value{
+		api.SubnetTopologyPublic:  refPublicRT,
+		api.SubnetTopologyPrivate: refPrivateRT,
+	})
+}
+
+// addResourcesForAttachments creates transit gateway attachments and routes
+// for each of the attachments in the route tables of the dedicated VPC
+func (c *ClusterResourceSet) addResourcesForAttachments(refRTs map[api.SubnetTopology]*gfn.Value) {
+	for _, attachment := range c.spec.VPC.Attachments {
+		var (
+			alias     string
+			dependsOn []string
+			target    map[string]interface{}
+		)
+		if attachment.TransitGatewayID != "" {
+			alias = "TGW" + strings.ToUpper(strings.TrimPrefix(attachment.TransitGatewayID, "tgw-"))
+			// routes cannot be created until the attachment exists
+			dependsOn = []string{"TransitGatewayAttachment" + alias}
+			target = map[string]interface{}{"TransitGatewayId": attachment.TransitGatewayID}
+			c.newResource(dependsOn[0], &gfn.AWSEC2TransitGatewayAttachment{
+				TransitGatewayId: gfn.NewString(attachment.TransitGatewayID),
+				VpcId:            c.vpc,
+				SubnetIds:        c.subnets[api.SubnetTopologyPrivate],
+			})
+		} else {
+			alias = "PCX" + strings.ToUpper(strings.TrimPrefix(attachment.PeeringConnectionID, "pcx-"))
+			target = map[string]interface{}{"VpcPeeringConnectionId": attachment.PeeringConnectionID}
+		}
+
+		for _, topology := range api.SubnetTopologies() {
+			for i, cidr := range attachment.DestinationCIDRs {
+				properties := map[string]interface{}{
+					"RouteTableId":         refRTs[topology],
+					"DestinationCidrBlock": cidr.String(),
+				}
+				for k, v := range target {
+					properties[k] = v
+				}
+				// goformation doesn't support TransitGatewayId in AWS::EC2::Route yet
+				c.newResource(fmt.Sprintf("%sRoute%s%d", topology, alias, i), &awsCloudFormationResource{
+					Type:       "AWS::EC2::Route",
+					Properties: properties,
+					DependsOn:  dependsOn,
+				})
+			}
+		}
+	}
 }
 
 func (c *ClusterResourceSet) importResourcesForVPC() {
@@ -104,6 +154,57 @@ func (c *ClusterResourceSet) importResourcesForVPC() {
 
 }
 
+// addResourcesForFlowLogs must be called after addResourcesForIAM,
+// as it may need to create an IAM role
+func (c *ClusterResourceSet) addResourcesForFlowLogs() {
+	flowLogs := c.spec.VPC.FlowLogs
+	if flowLogs == nil {
+		return
+	}
+
+	trafficType := flowLogs.TrafficType
+	if trafficType == "" {
+		trafficType = api.FlowLogsTrafficTypeAll
+	}
+
+	flowLog := &gfn.AWSEC2FlowLog{
+		ResourceId:   c.vpc,
+		ResourceType: gfn.NewString("VPC"),
+		TrafficType:  gfn.NewString(trafficType),
+	}
+
+	switch flowLogs.Destination {
+	case api.FlowLogsDestinationS3:
+		flowLog.LogDestinationType = gfn.NewString(api.FlowLogsDestinationS3)
+		flowLog.LogDestination = gfn.NewString(flowLogs.S3BucketARN)
+	default:
+		logGroup := &gfn.AWSLogsLogGroup{}
+		if flowLogs.RetentionInDays != nil {
+			logGroup.RetentionInDays = gfn.NewInteger(*flowLogs.RetentionInDays)
+		}
+		refLogGroup := c.newResource("FlowLogsLogGroup", logGroup)
+
+		c.rs.withIAM = true
+		role := gfn.AWSIAMRole{
+			AssumeRolePolicyDocument: makeAssumeRolePolicyDocument(api.ServicePrincipal("vpc-flow-logs", c.spec.Metadata.Region)),
+		}
+		setRolePathAndBoundary(&role, c.spec.IAM.Path, c.spec.IAM.PermissionsBoundary)
+		refRole := c.newResource("FlowLogsRole", &role)
+		c.rs.attachAllowPolicy("PolicyFlowLogs", refRole, gfn.MakeFnGetAttString("FlowLogsLogGroup.Arn"), []string{
+			"logs:CreateLogStream",
+			"logs:PutLogEvents",
+			"logs:DescribeLogGroups",
+			"logs:DescribeLogStreams",
+		})
+
+		flowLog.LogDestinationType = gfn.NewString(api.FlowLogsDestinationCloudWatchLogs)
+		flowLog.LogGroupName = refLogGroup
+		flowLog.DeliverLogsPermissionArn = gfn.MakeFnGetAttString("FlowLogsRole.Arn")
+	}
+
+	c.newResource("FlowLogs", flowLog)
+}
+
 func (c *ClusterResourceSet) addOutputsForVPC() {
 	if c.spec.VPC == nil {
 		c.spec.VPC = &api.ClusterVPC{}
@@ -196,8 +297,6 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 
 	desc := "worker nodes in group " + n.nodeGroupName
 
-	allInternalIPv4 := gfn.NewString(n.clusterSpec.VPC.CIDR.String())
-
 	refControlPlaneSG := makeImportValue(n.clusterStackName, outputs.ClusterSecurityGroup)
 
 	refNodeGroupLocalSG := n.newResource("SG", &gfn.AWSEC2SecurityGroup{
@@ -251,27 +350,35 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 		FromPort:              sgPortHTTPS,
 		ToPort:                sgPortHTTPS,
 	})
-	if *n.spec.SSH.Allow {
-		if n.spec.PrivateNetworking {
-			n.newResource("SSHIPv4", &gfn.AWSEC2SecurityGroupIngress{
-				GroupId:     refNodeGroupLocalSG,
-				CidrIp:      allInternalIPv4,
+	addResourcesForIngressRules(n.rs, n.clusterSpec, n.spec, refNodeGroupLocalSG)
+}
+
+// addResourcesForIngressRules adds default SSH rules as well as custom
+// ingress rules to the local security group of a nodegroup
+func addResourcesForIngressRules(rs *resourceSet, clusterSpec *api.ClusterConfig, ng *api.NodeGroup, refSG *gfn.Value) {
+	desc := "worker nodes in group " + ng.Name
+
+	if api.IsEnabled(ng.SSH.Allow) && !allowsSSH(ng.SecurityGroups.Ingress) {
+		if ng.PrivateNetworking {
+			rs.newResource("SSHIPv4", &gfn.AWSEC2SecurityGroupIngress{
+				GroupId:     refSG,
+				CidrIp:      gfn.NewString(clusterSpec.VPC.CIDR.String()),
 				Description: gfn.NewString("Allow SSH access to " + desc + " (private, only inside VPC)"),
 				IpProtocol:  sgProtoTCP,
 				FromPort:    sgPortSSH,
 				ToPort:      sgPortSSH,
 			})
 		} else {
-			n.newResource("SSHIPv4", &gfn.AWSEC2SecurityGroupIngress{
-				GroupId:     refNodeGroupLocalSG,
+			rs.newResource("SSHIPv4", &gfn.AWSEC2SecurityGroupIngress{
+				GroupId:     refSG,
 				CidrIp:      sgSourceAnywhereIPv4,
 				Description: gfn.NewString("Allow SSH access to " + desc),
 				IpProtocol:  sgProtoTCP,
 				FromPort:    sgPortSSH,
 				ToPort:      sgPortSSH,
 			})
-			n.newResource("SSHIPv6", &gfn.AWSEC2SecurityGroupIngress{
-				GroupId:     refNodeGroupLocalSG,
+			rs.newResource("SSHIPv6", &gfn.AWSEC2SecurityGroupIngress{
+				GroupId:     refSG,
 				CidrIpv6:    sgSourceAnywhereIPv6,
 				Description: gfn.NewString("Allow SSH access to " + desc),
 				IpProtocol:  sgProtoTCP,
@@ -280,4 +387,76 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 			})
 		}
 	}
+
+	for i, rule := range ng.SecurityGroups.Ingress {
+		description := rule.Description
+		if description == "" {
+			description = fmt.Sprintf("Allow access to %s (custom rule %d)", desc, i)
+		}
+		toPort := rule.FromPort
+		if rule.ToPort != nil {
+			toPort = *rule.ToPort
+		}
+		newIngress := func() *gfn.AWSEC2SecurityGroupIngress {
+			return &gfn.AWSEC2SecurityGroupIngress{
+				GroupId:     refSG,
+				Description: gfn.NewString(description),
+				IpProtocol:  gfn.NewString(rule.Protocol),
+				FromPort:    gfn.NewInteger(rule.FromPort),
+				ToPort:      gfn.NewInteger(toPort),
+			}
+		}
+		for j, cidr := range rule.CIDRs {
+			ingress := newIngress()
+			if cidr.IP.To4() != nil {
+				ingress.CidrIp = gfn.NewString(cidr.String())
+			} else {
+				ingress.CidrIpv6 = gfn.NewString(cidr.String())
+			}
+			rs.newResource(fmt.Sprintf("%s%dCIDR%d", customIngressPrefix, i, j), ingress)
+		}
+		for j, id := range rule.SourceSecurityGroupIDs {
+			ingress := newIngress()
+			ingress.SourceSecurityGroupId = gfn.NewString(id)
+			rs.newResource(fmt.Sprintf("%s%dSG%d", customIngressPrefix, i, j), ingress)
+		}
+	}
+}
+
+const customIngressPrefix = "CustomIngress"
+
+// allowsSSH checks whether any of the custom rules allows TCP port 22; ports
+// are ignored for protocol "-1", which allows all traffic
+func allowsSSH(rules []api.SecurityGroupIngress) bool {
+	for _, rule := range rules {
+		switch rule.Protocol {
+		case "-1":
+			return true
+		case "", "tcp", "6":
+		default:
+			continue
+		}
+		toPort := rule.FromPort
+		if rule.ToPort != nil {
+			toPort = *rule.ToPort
+		}
+		if rule.FromPort <= 22 && toPort >= 22 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNodeGroupIngressRuleResource checks whether a resource of a nodegroup
+// stack is one of the rules rendered by RenderNodeGroupIngressRules
+func IsNodeGroupIngressRuleResource(name string) bool {
+	return name == "SSHIPv4" || name == "SSHIPv6" || strings.HasPrefix(name, customIngressPrefix)
+}
+
+// RenderNodeGroupIngressRules renders only the ingress rules of the local
+// security group of a nodegroup, so these can be updated in an existing stack
+func RenderNodeGroupIngressRules(clusterSpec *api.ClusterConfig, ng *api.NodeGroup) ([]byte, error) {
+	rs := newResourceSet()
+	addResourcesForIngressRules(rs, clusterSpec, ng, gfn.MakeRef("SG"))
+	return rs.renderJSON()
 }
@@ -0,0 +1,281 @@
+package builder_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+type clusterTemplate struct {
+	Resources map[string]struct {
+		Type       string
+		Properties map[string]interface{}
+		DependsOn  []string
+	}
+}
+
+func renderClusterTemplate(cfg *api.ClusterConfig) (*clusterTemplate, *ClusterResourceSet) {
+	crs := NewClusterResourceSet(mockprovider.NewMockProvider(), cfg)
+	Expect(crs.AddAllResources()).To(Succeed())
+
+	templateBody, err := crs.RenderJSON()
+	Expect(err).ShouldNot(HaveOccurred())
+
+	t := &clusterTemplate{}
+	Expect(json.Unmarshal(templateBody, t)).To(Succeed())
+	return t, crs
+}
+
+func newClusterConfigWithDedicatedVPC() *api.ClusterConfig {
+	cfg := api.NewClusterConfig()
+	cfg.Metadata.Region = "us-west-2"
+	cfg.Metadata.Name = clusterName
+	cfg.AvailabilityZones = []string{"us-west-2b", "us-west-2a", "us-west-2c"}
+	*cfg.VPC.CIDR = api.DefaultCIDR()
+	Expect(vpc.SetSubnets(cfg)).To(Succeed())
+	return cfg
+}
+
+var _ = Describe("Cluster VPC template", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = newClusterConfigWithDedicatedVPC()
+	})
+
+	Context("without flow logs", func() {
+		It("should not have flow log resources", func() {
+			t, _ := renderClusterTemplate(cfg)
+			Expect(t.Resources).ToNot(HaveKey("FlowLogs"))
+			Expect(t.Resources).ToNot(HaveKey("FlowLogsLogGroup"))
+			Expect(t.Resources).ToNot(HaveKey("FlowLogsRole"))
+		})
+	})
+
+	Context("with flow logs delivered to CloudWatch Logs", func() {
+		BeforeEach(func() {
+			retention := 30
+			cfg.VPC.FlowLogs = &api.VPCFlowLogs{
+				TrafficType:     api.FlowLogsTrafficTypeReject,
+				RetentionInDays: &retention,
+			}
+			cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:role/eks-service-role"
+		})
+
+		It("should have flow log, log group and IAM role", func() {
+			t, crs := renderClusterTemplate(cfg)
+
+			Expect(crs.WithIAM()).To(BeTrue())
+
+			Expect(t.Resources).To(HaveKey("FlowLogs"))
+			flowLog := t.Resources["FlowLogs"]
+			Expect(flowLog.Type).To(Equal("AWS::EC2::FlowLog"))
+			Expect(flowLog.Properties["ResourceId"]).To(Equal(map[string]interface{}{"Ref": "VPC"}))
+			Expect(flowLog.Properties["ResourceType"]).To(Equal("VPC"))
+			Expect(flowLog.Properties["TrafficType"]).To(Equal("REJECT"))
+			Expect(flowLog.Properties["LogDestinationType"]).To(Equal("cloud-watch-logs"))
+			Expect(flowLog.Properties["LogGroupName"]).To(Equal(map[string]interface{}{"Ref": "FlowLogsLogGroup"}))
+			Expect(flowLog.Properties["DeliverLogsPermissionArn"]).To(Equal(map[string]interface{}{
+				"Fn::GetAtt": "FlowLogsRole.Arn",
+			}))
+
+			Expect(t.Resources).To(HaveKey("FlowLogsLogGroup"))
+			Expect(t.Resources["FlowLogsLogGroup"].Properties["RetentionInDays"]).To(BeNumerically("==", 30))
+
+			Expect(t.Resources).To(HaveKey("FlowLogsRole"))
+			Expect(t.Resources).To(HaveKey("PolicyFlowLogs"))
+		})
+	})
+
+	Context("with flow logs delivered to S3", func() {
+		BeforeEach(func() {
+			cfg.VPC.FlowLogs = &api.VPCFlowLogs{
+				Destination: api.FlowLogsDestinationS3,
+				S3BucketARN: "arn:aws:s3:::flow-logs-bucket/eks",
+			}
+		})
+
+		It("should have only the flow log", func() {
+			t, _ := renderClusterTemplate(cfg)
+
+			Expect(t.Resources).To(HaveKey("FlowLogs"))
+			flowLog := t.Resources["FlowLogs"]
+			Expect(flowLog.Properties["TrafficType"]).To(Equal("ALL"))
+			Expect(flowLog.Properties["LogDestinationType"]).To(Equal("s3"))
+			Expect(flowLog.Properties["LogDestination"]).To(Equal("arn:aws:s3:::flow-logs-bucket/eks"))
+
+			Expect(t.Resources).ToNot(HaveKey("FlowLogsLogGroup"))
+			Expect(t.Resources).ToNot(HaveKey("FlowLogsRole"))
+		})
+	})
+})
+
+var _ = Describe("Cluster VPC attachments template", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = newClusterConfigWithDedicatedVPC()
+		cfg.VPC.Attachments = []api.VPCAttachment{
+			{
+				TransitGatewayID: "tgw-0123abcd",
+				DestinationCIDRs: []*ipnet.IPNet{mustParseCIDR("10.0.0.0/8")},
+			},
+			{
+				PeeringConnectionID: "pcx-4567ef",
+				DestinationCIDRs:    []*ipnet.IPNet{mustParseCIDR("172.16.0.0/16"), mustParseCIDR("172.17.0.0/16")},
+			},
+		}
+	})
+
+	It("should have transit gateway attachment and routes", func() {
+		t, _ := renderClusterTemplate(cfg)
+
+		Expect(t.Resources).To(HaveKey("TransitGatewayAttachmentTGW0123ABCD"))
+		attachment := t.Resources["TransitGatewayAttachmentTGW0123ABCD"]
+		Expect(attachment.Type).To(Equal("AWS::EC2::TransitGatewayAttachment"))
+		Expect(attachment.Properties["TransitGatewayId"]).To(Equal("tgw-0123abcd"))
+		Expect(attachment.Properties["SubnetIds"]).To(HaveLen(3))
+
+		for _, topology := range []string{"Public", "Private"} {
+			name := topology + "RouteTGW0123ABCD0"
+			Expect(t.Resources).To(HaveKey(name))
+			route := t.Resources[name]
+			Expect(route.Type).To(Equal("AWS::EC2::Route"))
+			Expect(route.DependsOn).To(Equal([]string{"TransitGatewayAttachmentTGW0123ABCD"}))
+			Expect(route.Properties["TransitGatewayId"]).To(Equal("tgw-0123abcd"))
+			Expect(route.Properties["DestinationCidrBlock"]).To(Equal("10.0.0.0/8"))
+			Expect(route.Properties["RouteTableId"]).To(Equal(map[string]interface{}{"Ref": topology + "RouteTable"}))
+		}
+	})
+
+	It("should have peering routes", func() {
+		t, _ := renderClusterTemplate(cfg)
+
+		for _, topology := range []string{"Public", "Private"} {
+			for i, cidr := range []string{"172.16.0.0/16", "172.17.0.0/16"} {
+				name := fmt.Sprintf("%sRoutePCX4567EF%d", topology, i)
+				Expect(t.Resources).To(HaveKey(name))
+				route := t.Resources[name]
+				Expect(route.DependsOn).To(BeEmpty())
+				Expect(route.Properties["VpcPeeringConnectionId"]).To(Equal("pcx-4567ef"))
+				Expect(route.Properties["DestinationCidrBlock"]).To(Equal(cidr))
+			}
+		}
+	})
+})
+
+func mustParseCIDR(s string) *ipnet.IPNet {
+	cidr, err := ipnet.ParseCIDR(s)
+	Expect(err).ToNot(HaveOccurred())
+	return cidr
+}
+
+var _ = Describe("Nodegroup ingress rules", func() {
+	var (
+		cfg *api.ClusterConfig
+		ng  *api.NodeGroup
+	)
+
+	render := func() *clusterTemplate {
+		templateBody, err := RenderNodeGroupIngressRules(cfg, ng)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		t := &clusterTemplate{}
+		Expect(json.Unmarshal(templateBody, t)).To(Succeed())
+		return t
+	}
+
+	BeforeEach(func() {
+		cfg = newClusterConfigWithDedicatedVPC()
+		ng = cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+		ng.SSH.Allow = api.Enabled()
+	})
+
+	It("should only have default SSH rules", func() {
+		t := render()
+		Expect(t.Resources).To(HaveLen(2))
+		Expect(t.Resources["SSHIPv4"].Properties["CidrIp"]).To(Equal("0.0.0.0/0"))
+		Expect(t.Resources["SSHIPv6"].Properties["CidrIpv6"]).To(Equal("::/0"))
+		Expect(t.Resources["SSHIPv4"].Properties["GroupId"]).To(Equal(map[string]interface{}{"Ref": "SG"}))
+	})
+
+	It("should render custom rules for each CIDR and source security group", func() {
+		toPort := 8080
+		ng.SecurityGroups.Ingress = []api.SecurityGroupIngress{
+			{
+				Protocol: "tcp",
+				FromPort: 8000,
+				ToPort:   &toPort,
+				CIDRs:    []*ipnet.IPNet{mustParseCIDR("10.1.0.0/16"), mustParseCIDR("2001:db8::/32")},
+			},
+			{
+				Description:            "Allow ICMP from monitoring",
+				Protocol:               "icmp",
+				FromPort:               -1,
+				SourceSecurityGroupIDs: []string{"sg-123"},
+			},
+		}
+
+		t := render()
+		Expect(t.Resources).To(HaveKey("SSHIPv4"))
+		Expect(t.Resources).To(HaveKey("SSHIPv6"))
+
+		Expect(t.Resources).To(HaveKey("CustomIngress0CIDR0"))
+		rule := t.Resources["CustomIngress0CIDR0"]
+		Expect(rule.Type).To(Equal("AWS::EC2::SecurityGroupIngress"))
+		Expect(rule.Properties["CidrIp"]).To(Equal("10.1.0.0/16"))
+		Expect(rule.Properties["IpProtocol"]).To(Equal("tcp"))
+		Expect(rule.Properties["FromPort"]).To(BeNumerically("==", 8000))
+		Expect(rule.Properties["ToPort"]).To(BeNumerically("==", 8080))
+
+		Expect(t.Resources["CustomIngress0CIDR1"].Properties["CidrIpv6"]).To(Equal("2001:db8::/32"))
+		Expect(t.Resources["CustomIngress0CIDR1"].Properties).ToNot(HaveKey("CidrIp"))
+
+		rule = t.Resources["CustomIngress1SG0"]
+		Expect(rule.Properties["SourceSecurityGroupId"]).To(Equal("sg-123"))
+		Expect(rule.Properties["Description"]).To(Equal("Allow ICMP from monitoring"))
+		Expect(rule.Properties["ToPort"]).To(BeNumerically("==", -1))
+	})
+
+	It("should omit default SSH rules when a custom rule allows SSH", func() {
+		ng.SecurityGroups.Ingress = []api.SecurityGroupIngress{{
+			Protocol: "tcp",
+			FromPort: 22,
+			CIDRs:    []*ipnet.IPNet{mustParseCIDR("203.0.113.10/32")},
+		}}
+
+		t := render()
+		Expect(t.Resources).ToNot(HaveKey("SSHIPv4"))
+		Expect(t.Resources).ToNot(HaveKey("SSHIPv6"))
+		Expect(t.Resources["CustomIngress0CIDR0"].Properties["CidrIp"]).To(Equal("203.0.113.10/32"))
+		Expect(t.Resources["CustomIngress0CIDR0"].Properties["ToPort"]).To(BeNumerically("==", 22))
+	})
+
+	It("should omit default SSH rules when a custom rule allows all traffic", func() {
+		ng.SecurityGroups.Ingress = []api.SecurityGroupIngress{{
+			Protocol: "-1",
+			FromPort: -1,
+			CIDRs:    []*ipnet.IPNet{mustParseCIDR("10.0.0.0/8")},
+		}}
+
+		t := render()
+		Expect(t.Resources).ToNot(HaveKey("SSHIPv4"))
+		Expect(t.Resources).ToNot(HaveKey("SSHIPv6"))
+	})
+
+	It("should tell apart ingress rule resources", func() {
+		Expect(IsNodeGroupIngressRuleResource("SSHIPv4")).To(BeTrue())
+		Expect(IsNodeGroupIngressRuleResource("CustomIngress0SG1")).To(BeTrue())
+		Expect(IsNodeGroupIngressRuleResource("IngressInterCluster")).To(BeFalse())
+		Expect(IsNodeGroupIngressRuleResource("SG")).To(BeFalse())
+	})
+})
@@ -3,10 +3,12 @@ package manager
 import (
 	"fmt"
 	"regexp"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -16,6 +18,10 @@ import (
 const (
 	resourcesRootPath = "Resources"
 	outputsRootPath   = "Outputs"
+
+	// maxConcurrentStackRequests limits how many requests for
+	// individual stacks are made at the same time
+	maxConcurrentStackRequests = 8
 )
 
 var (
@@ -41,6 +47,13 @@ type StackCollection struct {
 	provider   api.ClusterProvider
 	spec       *api.ClusterConfig
 	sharedTags []*cloudformation.Tag
+
+	templateBucketMutex   sync.Mutex
+	defaultTemplateBucket string
+	uploadedTemplates     map[string]*s3.DeleteObjectInput
+
+	templateCacheMutex sync.Mutex
+	templateCache      map[string]string
 }
 
 func newTag(key, value string) *cloudformation.Tag {
@@ -68,6 +81,8 @@ func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, ta
 	input := &cloudformation.CreateStackInput{
 		StackName: i.StackName,
 	}
+	// CloudFormation has read the template once the stack creation is requested
+	defer c.deleteUploadedTemplate(*i.StackName)
 
 	for _, t := range c.sharedTags {
 		input.Tags = append(input.Tags, t)
@@ -76,7 +91,26 @@ func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, ta
 		input.Tags = append(input.Tags, newTag(k, v))
 	}
 
-	input.SetTemplateBody(string(templateBody))
+	templateURL, err := c.templateURL(*i.StackName, templateBody)
+	if err != nil {
+		return err
+	}
+	if templateURL != "" {
+		input.SetTemplateURL(templateURL)
+	} else {
+		input.SetTemplateBody(string(templateBody))
+	}
+
+	if c.spec.Metadata.Protection {
+		input.SetEnableTerminationProtection(true)
+		policy, err := makeStackPolicy(string(templateBody), true)
+		if err != nil {
+			return err
+		}
+		if policy != "" {
+			input.SetStackPolicyBody(policy)
+		}
+	}
 
 	if withIAM {
 		input.SetCapabilities(stackCapabilitiesIAM)
@@ -131,9 +165,16 @@ func (c *StackCollection) CreateStack(name string, stack builder.ResourceSet, ta
 
 // UpdateStack will update a CloudFormation stack by creating and executing a ChangeSet
 func (c *StackCollection) UpdateStack(stackName string, changeSetName string, description string, template []byte, parameters map[string]string) error {
+	return c.updateStack(stackName, changeSetName, description, template, parameters, nil)
+}
+
+// updateStack is like UpdateStack, but it also replaces all tags of the stack, unless tags is nil
+func (c *StackCollection) updateStack(stackName string, changeSetName string, description string, template []byte, parameters map[string]string, tags []*cloudformation.Tag) error {
 	logger.Info(description)
+	defer c.forgetStackTemplate(stackName)
+	defer c.deleteUploadedTemplate(stackName)
 	i := &Stack{StackName: &stackName}
-	if err := c.doCreateChangeSetRequest(i, changeSetName, description, template, parameters, true); err != nil {
+	if err := c.doCreateChangeSetRequest(i, changeSetName, description, template, parameters, tags, true); err != nil {
 		return err
 	}
 	if err := c.doWaitUntilChangeSetIsCreated(i, changeSetName); err != nil {
@@ -166,22 +207,51 @@ func (c *StackCollection) DescribeStack(i *Stack) (*Stack, error) {
 	return resp.Stacks[0], nil
 }
 
-// ListStacks gets all of CloudFormation stacks
+// ListStacks returns all stacks with names that match nameRegex; stacks are described in batches
+// with paged DescribeStacks requests, unless deleted stacks are requested with statusFilters, as
+// DescribeStacks only returns deleted stacks when these are requested by ID
 func (c *StackCollection) ListStacks(nameRegex string, statusFilters ...string) ([]*Stack, error) {
+	re, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list stacks")
+	}
+	for _, status := range statusFilters {
+		if status == cloudformation.StackStatusDeleteComplete {
+			return c.listStacksWithSummaries(re, statusFilters)
+		}
+	}
+	return c.describeAllStacks(func(s *Stack) bool {
+		return re.MatchString(*s.StackName) && stackStatusMatches(s, statusFilters)
+	})
+}
+
+// describeAllStacks pages through all stacks in the region and returns those that match
+func (c *StackCollection) describeAllStacks(match func(*Stack) bool) ([]*Stack, error) {
+	stacks := []*Stack{}
+	pager := func(p *cloudformation.DescribeStacksOutput, _ bool) bool {
+		for _, s := range p.Stacks {
+			if match(s) {
+				stacks = append(stacks, s)
+			}
+		}
+		return true
+	}
+	if err := c.provider.CloudFormation().DescribeStacksPages(&cloudformation.DescribeStacksInput{}, pager); err != nil {
+		return nil, err
+	}
+	return stacks, nil
+}
+
+// listStacksWithSummaries uses ListStacks, which is the only way to find deleted stacks,
+// and describes each of the matching stacks separately
+func (c *StackCollection) listStacksWithSummaries(re *regexp.Regexp, statusFilters []string) ([]*Stack, error) {
 	var (
 		subErr error
 		stack  *Stack
 	)
 
-	re, err := regexp.Compile(nameRegex)
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot list stacks")
-	}
 	input := &cloudformation.ListStacksInput{
-		StackStatusFilter: defaultStackStatusFilter(),
-	}
-	if len(statusFilters) > 0 {
-		input.StackStatusFilter = aws.StringSlice(statusFilters)
+		StackStatusFilter: aws.StringSlice(statusFilters),
 	}
 	stacks := []*Stack{}
 
@@ -206,6 +276,44 @@ func (c *StackCollection) ListStacks(nameRegex string, statusFilters ...string)
 	return stacks, nil
 }
 
+// forEachStack calls fn with the index of each of the stacks concurrently, with at
+// most maxConcurrentStackRequests calls in flight, and returns the first error
+func forEachStack(stacks []*Stack, fn func(i int, s *Stack) error) error {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, maxConcurrentStackRequests)
+	)
+	errs := make([]error, len(stacks))
+	for i := range stacks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i, stacks[i])
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stackStatusMatches(s *Stack, statusFilters []string) bool {
+	if len(statusFilters) == 0 {
+		statusFilters = allNonDeletedStackStatuses()
+	}
+	for _, status := range statusFilters {
+		if *s.StackStatus == status {
+			return true
+		}
+	}
+	return false
+}
+
 // StackStatusIsNotTransitional will return true when stack statate is non-transitional
 func (*StackCollection) StackStatusIsNotTransitional(s *Stack) bool {
 	for _, state := range nonTransitionalReadyStackStatuses() {
@@ -273,10 +381,6 @@ func allNonDeletedStackStatuses() []string {
 	}
 }
 
-func defaultStackStatusFilter() []*string {
-	return aws.StringSlice(allNonDeletedStackStatuses())
-}
-
 // DeleteStackByName sends a request to delete the stack
 func (c *StackCollection) DeleteStackByName(name string) (*Stack, error) {
 	i := &Stack{StackName: &name}
@@ -377,9 +481,9 @@ func (c *StackCollection) errStackNotFound() error {
 
 // DescribeStacks describes the existing stacks
 func (c *StackCollection) DescribeStacks() ([]*Stack, error) {
-	stacks, err := c.ListStacks(fmtStacksRegexForCluster(c.spec.Metadata.Name))
+	stacks, err := c.listClusterStacks()
 	if err != nil {
-		return nil, errors.Wrapf(err, "describing CloudFormation stacks for %q", c.spec.Metadata.Name)
+		return nil, err
 	}
 	if len(stacks) == 0 {
 		return nil, c.errStackNotFound()
@@ -387,6 +491,27 @@ func (c *StackCollection) DescribeStacks() ([]*Stack, error) {
 	return stacks, nil
 }
 
+// listClusterStacks returns all stacks of the cluster that were not deleted, stacks are
+// matched by name and, when these have one, by the cluster name tag, so that stacks of
+// other clusters with a similar name are ignored; stacks created by old versions of
+// eksctl have no tags and are matched by name only
+func (c *StackCollection) listClusterStacks() ([]*Stack, error) {
+	re := regexp.MustCompile(fmtStacksRegexForCluster(regexp.QuoteMeta(c.spec.Metadata.Name)))
+	stacks, err := c.describeAllStacks(func(s *Stack) bool {
+		if !re.MatchString(*s.StackName) || !stackStatusMatches(s, nil) {
+			return false
+		}
+		if name := getClusterNameTag(s); name != "" {
+			return name == c.spec.Metadata.Name
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing CloudFormation stacks for %q", c.spec.Metadata.Name)
+	}
+	return stacks, nil
+}
+
 // DescribeStackEvents describes the events that have occurred on the stack
 func (c *StackCollection) DescribeStackEvents(i *Stack) ([]*cloudformation.StackEvent, error) {
 	input := &cloudformation.DescribeStackEventsInput{
@@ -432,7 +557,7 @@ func (c *StackCollection) LookupCloudTrailEvents(i *Stack) ([]*cloudtrail.Event,
 }
 
 func (c *StackCollection) doCreateChangeSetRequest(i *Stack, changeSetName string, description string, templateBody []byte,
-	parameters map[string]string, withIAM bool) error {
+	parameters map[string]string, tags []*cloudformation.Tag, withIAM bool) error {
 	input := &cloudformation.CreateChangeSetInput{
 		StackName:     i.StackName,
 		ChangeSetName: &changeSetName,
@@ -441,7 +566,15 @@ func (c *StackCollection) doCreateChangeSetRequest(i *Stack, changeSetName strin
 
 	input.SetChangeSetType(cloudformation.ChangeSetTypeUpdate)
 
-	input.SetTemplateBody(string(templateBody))
+	templateURL, err := c.templateURL(*i.StackName, templateBody)
+	if err != nil {
+		return err
+	}
+	if templateURL != "" {
+		input.SetTemplateURL(templateURL)
+	} else {
+		input.SetTemplateBody(string(templateBody))
+	}
 
 	if withIAM {
 		input.SetCapabilities(stackCapabilitiesIAM)
@@ -459,6 +592,10 @@ func (c *StackCollection) doCreateChangeSetRequest(i *Stack, changeSetName strin
 		input.Parameters = append(input.Parameters, p)
 	}
 
+	if tags != nil {
+		input.SetTags(tags)
+	}
+
 	logger.Debug("creating changeSet, input = %#v", input)
 	s, err := c.provider.CloudFormation().CreateChangeSet(input)
 	if err != nil {
@@ -0,0 +1,159 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// driftDetectionPollInterval is how often the status of drift detection is checked
+var driftDetectionPollInterval = 5 * time.Second
+
+// StackDrift holds the result of drift detection for a stack
+type StackDrift struct {
+	StackName         string           `json:"stackName"`
+	StackDriftStatus  string           `json:"stackDriftStatus"`
+	DriftedResources  []*ResourceDrift `json:"driftedResources,omitempty"`
+	DetectionFinished time.Time        `json:"detectionFinished"`
+}
+
+// ResourceDrift describes a resource that was modified or deleted outside of CloudFormation
+type ResourceDrift struct {
+	StackName           string                `json:"-"`
+	LogicalResourceID   string                `json:"logicalResourceID"`
+	PhysicalResourceID  string                `json:"physicalResourceID,omitempty"`
+	ResourceType        string                `json:"resourceType"`
+	DriftStatus         string                `json:"driftStatus"`
+	PropertyDifferences []*PropertyDifference `json:"propertyDifferences,omitempty"`
+}
+
+// PropertyDifference describes how an actual property value differs from the expected one
+type PropertyDifference struct {
+	PropertyPath   string `json:"propertyPath"`
+	DifferenceType string `json:"differenceType"`
+	ExpectedValue  string `json:"expectedValue"`
+	ActualValue    string `json:"actualValue"`
+}
+
+// DetectStacksDrift runs drift detection on all stacks of the cluster,
+// waits for it to complete and returns drifted resources of each stack
+func (c *StackCollection) DetectStacksDrift() ([]*StackDrift, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	detectionIDs := map[string]string{}
+	stackNames := []string{}
+	for _, s := range stacks {
+		if *s.StackStatus == cloudformation.StackStatusDeleteComplete {
+			continue
+		}
+		if !c.StackStatusIsNotTransitional(s) {
+			logger.Warning("skipping stack %q, as it is currently in %q state", *s.StackName, *s.StackStatus)
+			continue
+		}
+		id, err := c.startDriftDetection(s)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("started drift detection on stack %q", *s.StackName)
+		detectionIDs[*s.StackName] = id
+		stackNames = append(stackNames, *s.StackName)
+	}
+
+	results := []*StackDrift{}
+	for _, name := range stackNames {
+		result, err := c.waitForDriftDetection(name, detectionIDs[name])
+		if err != nil {
+			return nil, err
+		}
+		if result.StackDriftStatus == cloudformation.StackDriftStatusDrifted {
+			if result.DriftedResources, err = c.describeDriftedResources(name); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *StackCollection) startDriftDetection(s *Stack) (string, error) {
+	input := &cloudformation.DetectStackDriftInput{
+		StackName: s.StackName,
+	}
+	output, err := c.provider.CloudFormation().DetectStackDrift(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "starting drift detection on stack %q", *s.StackName)
+	}
+	return *output.StackDriftDetectionId, nil
+}
+
+func (c *StackCollection) waitForDriftDetection(name, detectionID string) (*StackDrift, error) {
+	input := &cloudformation.DescribeStackDriftDetectionStatusInput{
+		StackDriftDetectionId: aws.String(detectionID),
+	}
+	timer := time.After(c.provider.WaitTimeout())
+	for {
+		output, err := c.provider.CloudFormation().DescribeStackDriftDetectionStatus(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing drift detection status of stack %q", name)
+		}
+		switch *output.DetectionStatus {
+		case cloudformation.StackDriftDetectionStatusDetectionComplete:
+			return &StackDrift{
+				StackName:         name,
+				StackDriftStatus:  aws.StringValue(output.StackDriftStatus),
+				DetectionFinished: aws.TimeValue(output.Timestamp),
+			}, nil
+		case cloudformation.StackDriftDetectionStatusDetectionFailed:
+			return nil, fmt.Errorf("drift detection on stack %q failed: %s", name, aws.StringValue(output.DetectionStatusReason))
+		}
+		logger.Debug("waiting for drift detection on stack %q to complete", name)
+		select {
+		case <-time.After(driftDetectionPollInterval):
+		case <-timer:
+			return nil, fmt.Errorf("timed out (after %s) waiting for drift detection on stack %q", c.provider.WaitTimeout(), name)
+		}
+	}
+}
+
+func (c *StackCollection) describeDriftedResources(name string) ([]*ResourceDrift, error) {
+	input := &cloudformation.DescribeStackResourceDriftsInput{
+		StackName: aws.String(name),
+		StackResourceDriftStatusFilters: aws.StringSlice([]string{
+			cloudformation.StackResourceDriftStatusModified,
+			cloudformation.StackResourceDriftStatusDeleted,
+		}),
+	}
+	resources := []*ResourceDrift{}
+	pager := func(p *cloudformation.DescribeStackResourceDriftsOutput, _ bool) bool {
+		for _, d := range p.StackResourceDrifts {
+			r := &ResourceDrift{
+				StackName:          name,
+				LogicalResourceID:  aws.StringValue(d.LogicalResourceId),
+				PhysicalResourceID: aws.StringValue(d.PhysicalResourceId),
+				ResourceType:       aws.StringValue(d.ResourceType),
+				DriftStatus:        aws.StringValue(d.StackResourceDriftStatus),
+			}
+			for _, diff := range d.PropertyDifferences {
+				r.PropertyDifferences = append(r.PropertyDifferences, &PropertyDifference{
+					PropertyPath:   aws.StringValue(diff.PropertyPath),
+					DifferenceType: aws.StringValue(diff.DifferenceType),
+					ExpectedValue:  aws.StringValue(diff.ExpectedValue),
+					ActualValue:    aws.StringValue(diff.ActualValue),
+				})
+			}
+			resources = append(resources, r)
+		}
+		return true
+	}
+	if err := c.provider.CloudFormation().DescribeStackResourceDriftsPages(input, pager); err != nil {
+		return nil, errors.Wrapf(err, "describing drifted resources of stack %q", name)
+	}
+	return resources, nil
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection drift detection", func() {
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+
+		statusCalls int
+	)
+
+	stackNames := []string{"eksctl-test-cluster-cluster", "eksctl-test-cluster-nodegroup-ng-1"}
+
+	BeforeEach(func() {
+		driftDetectionPollInterval = time.Millisecond
+		statusCalls = 0
+
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		sc = NewStackCollection(p, cfg)
+
+		p.MockCloudFormation().On("DescribeStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(p *cfn.DescribeStacksOutput, last bool) (shouldContinue bool))
+			out := &cfn.DescribeStacksOutput{}
+			for _, name := range stackNames {
+				out.Stacks = append(out.Stacks, &cfn.Stack{
+					StackName:   aws.String(name),
+					StackStatus: aws.String(cfn.StackStatusCreateComplete),
+				})
+			}
+			consume(out, true)
+		}).Return(nil)
+
+		for _, name := range stackNames {
+			name := name
+
+			p.MockCloudFormation().On("DetectStackDrift", mock.MatchedBy(func(input *cfn.DetectStackDriftInput) bool {
+				return *input.StackName == name
+			})).Return(&cfn.DetectStackDriftOutput{
+				StackDriftDetectionId: aws.String("detection-" + name),
+			}, nil)
+		}
+
+		p.MockCloudFormation().On("DescribeStackDriftDetectionStatus", mock.Anything).Return(func(input *cfn.DescribeStackDriftDetectionStatusInput) *cfn.DescribeStackDriftDetectionStatusOutput {
+			statusCalls++
+			if statusCalls == 1 {
+				return &cfn.DescribeStackDriftDetectionStatusOutput{
+					DetectionStatus: aws.String(cfn.StackDriftDetectionStatusDetectionInProgress),
+				}
+			}
+			driftStatus := cfn.StackDriftStatusInSync
+			if *input.StackDriftDetectionId == "detection-eksctl-test-cluster-nodegroup-ng-1" {
+				driftStatus = cfn.StackDriftStatusDrifted
+			}
+			return &cfn.DescribeStackDriftDetectionStatusOutput{
+				DetectionStatus:  aws.String(cfn.StackDriftDetectionStatusDetectionComplete),
+				StackDriftStatus: aws.String(driftStatus),
+			}
+		}, nil)
+
+		p.MockCloudFormation().On("DescribeStackResourceDriftsPages", mock.MatchedBy(func(input *cfn.DescribeStackResourceDriftsInput) bool {
+			return *input.StackName == "eksctl-test-cluster-nodegroup-ng-1"
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(*cfn.DescribeStackResourceDriftsOutput, bool) bool)
+			consume(&cfn.DescribeStackResourceDriftsOutput{
+				StackResourceDrifts: []*cfn.StackResourceDrift{{
+					LogicalResourceId:        aws.String("SSHIPv4"),
+					PhysicalResourceId:       aws.String("SSHIPv4-123"),
+					ResourceType:             aws.String("AWS::EC2::SecurityGroupIngress"),
+					StackResourceDriftStatus: aws.String(cfn.StackResourceDriftStatusModified),
+					PropertyDifferences: []*cfn.PropertyDifference{{
+						PropertyPath:   aws.String("/CidrIp"),
+						DifferenceType: aws.String(cfn.DifferenceTypeNotEqual),
+						ExpectedValue:  aws.String("0.0.0.0/0"),
+						ActualValue:    aws.String("10.0.0.0/8"),
+					}},
+				}},
+			}, true)
+		}).Return(nil)
+	})
+
+	It("should report drifted resources of each stack", func() {
+		results, err := sc.DetectStacksDrift()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+
+		Expect(results[0].StackName).To(Equal("eksctl-test-cluster-cluster"))
+		Expect(results[0].StackDriftStatus).To(Equal(cfn.StackDriftStatusInSync))
+		Expect(results[0].DriftedResources).To(BeEmpty())
+
+		Expect(results[1].StackDriftStatus).To(Equal(cfn.StackDriftStatusDrifted))
+		Expect(results[1].DriftedResources).To(HaveLen(1))
+		r := results[1].DriftedResources[0]
+		Expect(r.LogicalResourceID).To(Equal("SSHIPv4"))
+		Expect(r.DriftStatus).To(Equal(cfn.StackResourceDriftStatusModified))
+		Expect(r.PropertyDifferences).To(HaveLen(1))
+		Expect(r.PropertyDifferences[0].ExpectedValue).To(Equal("0.0.0.0/0"))
+		Expect(r.PropertyDifferences[0].ActualValue).To(Equal("10.0.0.0/8"))
+
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStackDriftDetectionStatus", 3)).To(BeTrue())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStackResourceDriftsPages", 1)).To(BeTrue())
+	})
+})
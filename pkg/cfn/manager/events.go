@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// stackEventsMaxClockSkew allows for events that were recorded by CloudFormation
+	// just before the wait has started, when the local clock is ahead
+	stackEventsMaxClockSkew = 30 * time.Second
+)
+
+var (
+	// stackEventsPollInterval is how often new stack events are fetched
+	stackEventsPollInterval = 10 * time.Second
+	// stackEventsWriter is where stack events are written as JSON lines
+	stackEventsWriter io.Writer = os.Stdout
+)
+
+// StackEvent is the JSON representation of a stack event
+type StackEvent struct {
+	Timestamp            time.Time `json:"timestamp"`
+	StackName            string    `json:"stackName"`
+	LogicalResourceID    string    `json:"logicalResourceID"`
+	PhysicalResourceID   string    `json:"physicalResourceID,omitempty"`
+	ResourceType         string    `json:"resourceType"`
+	ResourceStatus       string    `json:"resourceStatus"`
+	ResourceStatusReason string    `json:"resourceStatusReason,omitempty"`
+}
+
+type stackEventsStreamer struct {
+	c      *StackCollection
+	stack  *Stack
+	format string
+	since  time.Time
+	seen   map[string]bool
+
+	stopOnce sync.Once
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// streamStackEvents polls for new events of the given stack in the background and logs each of
+// these as they occur, until the returned stop function is called; stop logs any remaining events
+// and it's safe to call it more than once
+func (c *StackCollection) streamStackEvents(i *Stack) (stop func(), streaming bool) {
+	format := c.provider.StackEventsFormat()
+	if format == api.StackEventsNone {
+		return func() {}, false
+	}
+
+	s := &stackEventsStreamer{
+		c:      c,
+		stack:  i,
+		format: format,
+		since:  time.Now().Add(-stackEventsMaxClockSkew),
+		seen:   map[string]bool{},
+		done:   make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			select {
+			case <-s.done:
+				s.poll() // ensure to log events that occurred since last poll
+				return
+			case <-time.After(stackEventsPollInterval):
+				s.poll()
+			}
+		}
+	}()
+
+	return s.stop, true
+}
+
+func (s *stackEventsStreamer) stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+	})
+}
+
+func (s *stackEventsStreamer) poll() {
+	input := &cfn.DescribeStackEventsInput{
+		StackName: s.stack.StackName,
+	}
+	if api.IsSetAndNonEmptyString(s.stack.StackId) {
+		input.StackName = s.stack.StackId
+	}
+
+	// events are returned in reverse chronological order, so it's
+	// only necessary to page through until a known event is found
+	newEvents := []*cfn.StackEvent{}
+	pager := func(p *cfn.DescribeStackEventsOutput, _ bool) bool {
+		for _, e := range p.StackEvents {
+			if s.seen[*e.EventId] || aws.TimeValue(e.Timestamp).Before(s.since) {
+				return false
+			}
+			newEvents = append(newEvents, e)
+		}
+		return true
+	}
+	if err := s.c.provider.CloudFormation().DescribeStackEventsPages(input, pager); err != nil {
+		logger.Debug("describing CloudFormation stack %q events: %v", *s.stack.StackName, err)
+		return
+	}
+
+	for i := len(newEvents) - 1; i >= 0; i-- {
+		e := newEvents[i]
+		s.seen[*e.EventId] = true
+		s.log(e)
+	}
+}
+
+func (s *stackEventsStreamer) log(e *cfn.StackEvent) {
+	if s.format == api.StackEventsJSON {
+		line, err := json.Marshal(StackEvent{
+			Timestamp:            aws.TimeValue(e.Timestamp),
+			StackName:            aws.StringValue(e.StackName),
+			LogicalResourceID:    aws.StringValue(e.LogicalResourceId),
+			PhysicalResourceID:   aws.StringValue(e.PhysicalResourceId),
+			ResourceType:         aws.StringValue(e.ResourceType),
+			ResourceStatus:       aws.StringValue(e.ResourceStatus),
+			ResourceStatusReason: aws.StringValue(e.ResourceStatusReason),
+		})
+		if err != nil {
+			logger.Debug("marshalling stack event: %v", err)
+			return
+		}
+		fmt.Fprintln(stackEventsWriter, string(line))
+		return
+	}
+
+	msg := formatStackEvent(e)
+	if strings.HasSuffix(aws.StringValue(e.ResourceStatus), "_FAILED") {
+		logger.Critical("%s", msg)
+	} else {
+		logger.Info("%s", msg)
+	}
+}
+
+// formatStackEvent formats an event in a compact way, e.g.:
+// "[12:01:05] AWS::EC2::VPC/VPC: CREATE_COMPLETE"
+func formatStackEvent(e *cfn.StackEvent) string {
+	msg := fmt.Sprintf("[%s] %s/%s: %s",
+		aws.TimeValue(e.Timestamp).Local().Format("15:04:05"),
+		aws.StringValue(e.ResourceType), aws.StringValue(e.LogicalResourceId), aws.StringValue(e.ResourceStatus))
+	if reason := aws.StringValue(e.ResourceStatusReason); reason != "" {
+		msg = fmt.Sprintf("%s – %s", msg, reason)
+	}
+	return msg
+}
@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection stack events", func() {
+	var (
+		p   *mockprovider.MockProvider
+		sc  *StackCollection
+		out *bytes.Buffer
+
+		// events in reverse chronological order, like the API returns them
+		events []*cfn.StackEvent
+	)
+
+	newEvent := func(id, logicalID, status string, t time.Time) *cfn.StackEvent {
+		return &cfn.StackEvent{
+			EventId:           aws.String(id),
+			StackName:         aws.String("test-stack"),
+			LogicalResourceId: aws.String(logicalID),
+			ResourceType:      aws.String("AWS::EC2::VPC"),
+			ResourceStatus:    aws.String(status),
+			Timestamp:         aws.Time(t),
+		}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		sc = NewStackCollection(p, cfg)
+
+		out = &bytes.Buffer{}
+		stackEventsWriter = out
+		stackEventsPollInterval = time.Hour
+
+		now := time.Now()
+		events = []*cfn.StackEvent{
+			newEvent("3", "VPC", cfn.ResourceStatusCreateComplete, now),
+			newEvent("2", "VPC", cfn.ResourceStatusCreateInProgress, now.Add(-time.Second)),
+			newEvent("1", "VPC", cfn.ResourceStatusDeleteComplete, now.Add(-time.Hour)),
+		}
+
+		p.MockCloudFormation().On("DescribeStackEventsPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(*cfn.DescribeStackEventsOutput, bool) bool)
+			consume(&cfn.DescribeStackEventsOutput{StackEvents: events}, true)
+		}).Return(nil)
+	})
+
+	It("should write new events as JSON lines in chronological order", func() {
+		s := &stackEventsStreamer{
+			c:      sc,
+			stack:  &Stack{StackName: aws.String("test-stack")},
+			format: api.StackEventsJSON,
+			since:  time.Now().Add(-time.Minute),
+			seen:   map[string]bool{},
+		}
+
+		s.poll()
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		e := StackEvent{}
+		Expect(json.Unmarshal([]byte(lines[0]), &e)).To(Succeed())
+		Expect(e.ResourceStatus).To(Equal(cfn.ResourceStatusCreateInProgress))
+		Expect(e.LogicalResourceID).To(Equal("VPC"))
+		Expect(json.Unmarshal([]byte(lines[1]), &e)).To(Succeed())
+		Expect(e.ResourceStatus).To(Equal(cfn.ResourceStatusCreateComplete))
+
+		out.Reset()
+		events = append([]*cfn.StackEvent{newEvent("4", "Subnet", cfn.ResourceStatusCreateFailed, time.Now())}, events...)
+		s.poll()
+		lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0]).To(ContainSubstring(`"logicalResourceID":"Subnet"`))
+	})
+
+	It("should not stream events when disabled", func() {
+		stop, streaming := sc.streamStackEvents(&Stack{StackName: aws.String("test-stack")})
+		stop()
+		Expect(streaming).To(BeFalse())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStackEventsPages", 0)).To(BeTrue())
+	})
+
+	It("should format events compactly", func() {
+		e := newEvent("5", "NodeGroup", cfn.ResourceStatusCreateFailed, time.Date(2019, 5, 1, 12, 1, 5, 0, time.Local))
+		e.ResourceStatusReason = aws.String("Resource creation cancelled")
+		e.ResourceType = aws.String("AWS::AutoScaling::AutoScalingGroup")
+		Expect(formatStackEvent(e)).To(Equal("[12:01:05] AWS::AutoScaling::AutoScalingGroup/NodeGroup: CREATE_FAILED – Resource creation cancelled"))
+	})
+})
@@ -0,0 +1,314 @@
+package manager
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/lock"
+)
+
+const (
+	// LockTag is the tag of the cluster stack that holds the lock of the cluster
+	LockTag = "alpha.eksctl.io/lock"
+
+	// tag values are limited to 256 characters, and the set of characters is
+	// limited too, so the lock is stored as base64-encoded JSON
+	maxLockTagLength = 256
+)
+
+// stackLockBackend stores the lock as a tag of the cluster stack, tags can only
+// be changed with a stack update, and CloudFormation doesn't allow concurrent
+// updates, so the lock is read back after it's created to check who won
+type stackLockBackend struct {
+	c *StackCollection
+}
+
+// NewLockBackend returns a lock backend that uses a tag of the cluster stack
+func (c *StackCollection) NewLockBackend() lock.Backend {
+	return &stackLockBackend{c: c}
+}
+
+func (b *stackLockBackend) describe() (*Stack, *lock.Lock, error) {
+	s, err := b.c.DescribeStack(&Stack{StackName: aws.String(b.c.makeClusterStackName())})
+	if err != nil {
+		if awsErr, ok := errors.Cause(err).(awserr.Error); ok && awsErr.Code() == "ValidationError" && strings.Contains(awsErr.Message(), "does not exist") {
+			// the cluster stack is gone, e.g. after the cluster was deleted, and so is the lock
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if !isUpdatable(s) {
+		// a lock in a stack that cannot be updated could never be released
+		logger.Debug("ignoring lock of stack %q, as it cannot be updated in status %q", *s.StackName, *s.StackStatus)
+		return s, nil, nil
+	}
+	l, err := decodeLockTag(s)
+	return s, l, err
+}
+
+// isUpdatable checks whether CloudFormation accepts updates of the stack in its current status
+func isUpdatable(s *Stack) bool {
+	switch aws.StringValue(s.StackStatus) {
+	case cloudformation.StackStatusCreateFailed,
+		cloudformation.StackStatusRollbackFailed,
+		cloudformation.StackStatusRollbackComplete,
+		cloudformation.StackStatusDeleteFailed,
+		cloudformation.StackStatusUpdateRollbackFailed:
+		return false
+	}
+	return true
+}
+
+func decodeLockTag(s *Stack) (*lock.Lock, error) {
+	for _, tag := range s.Tags {
+		if *tag.Key != LockTag {
+			continue
+		}
+		data, err := base64.RawURLEncoding.DecodeString(*tag.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decoding tag %q of stack %q", LockTag, *s.StackName)
+		}
+		return lock.Decode(string(data))
+	}
+	return nil, nil
+}
+
+// Get returns the lock stored in the tag of the cluster stack, if any
+func (b *stackLockBackend) Get() (*lock.Lock, error) {
+	_, l, err := b.describe()
+	return l, err
+}
+
+// Create adds the lock tag to the cluster stack, unless it's there already
+func (b *stackLockBackend) Create(l *lock.Lock) error {
+	s, current, err := b.describe()
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		return lock.ErrAlreadyLocked
+	}
+	if s == nil {
+		logger.Warning("cluster stack %q doesn't exist, cluster %q cannot be locked", b.c.makeClusterStackName(), b.c.spec.Metadata.Name)
+		return nil
+	}
+	if !isUpdatable(s) {
+		logger.Warning("cluster stack %q cannot be updated in status %q, cluster %q cannot be locked", *s.StackName, *s.StackStatus, b.c.spec.Metadata.Name)
+		return nil
+	}
+
+	value, err := encodeLockTag(l)
+	if err != nil {
+		return err
+	}
+	tags := append([]*cloudformation.Tag{}, s.Tags...)
+	tags = append(tags, newTag(LockTag, value))
+	if err := b.updateTags(s, "lock", "locking cluster", tags); err != nil {
+		if current, _ := b.Get(); current != nil && current.ID != l.ID {
+			return lock.ErrAlreadyLocked
+		}
+		return err
+	}
+
+	current, err = b.Get()
+	if err != nil {
+		return err
+	}
+	if current == nil || current.ID != l.ID {
+		return lock.ErrAlreadyLocked
+	}
+	return nil
+}
+
+// Delete removes the lock tag from the cluster stack, if it holds the given lock
+func (b *stackLockBackend) Delete(l *lock.Lock) error {
+	s, current, err := b.describe()
+	if err != nil || current == nil || current.ID != l.ID {
+		return err
+	}
+	tags := []*cloudformation.Tag{}
+	for _, tag := range s.Tags {
+		if *tag.Key != LockTag {
+			tags = append(tags, tag)
+		}
+	}
+	return b.updateTags(s, "unlock", "unlocking cluster", tags)
+}
+
+func (b *stackLockBackend) updateTags(s *Stack, action, description string, tags []*cloudformation.Tag) error {
+	template, err := b.c.GetStackTemplate(*s.StackName)
+	if err != nil {
+		return errors.Wrapf(err, "getting template of stack %q", *s.StackName)
+	}
+	return b.c.updateStack(*s.StackName, b.c.MakeChangeSetName(action), description, []byte(template), nil, tags)
+}
+
+// lockStackBackend stores the lock as a tag of a dedicated stack, which exists only
+// while the lock is held; CloudFormation refuses to create a stack that exists already,
+// so only one operation can create it, and unlike stackLockBackend it never updates
+// the cluster stack, so it also works when the cluster stack cannot be updated
+type lockStackBackend struct {
+	c *StackCollection
+}
+
+// lockStackPollInterval is how often the status of the lock stack is checked
+var lockStackPollInterval = 2 * time.Second
+
+// lockStackTemplate has a resource that doesn't create anything, as a template must have one
+const lockStackTemplate = `{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Description": "Lock of an EKS cluster, held while eksctl is running a mutating operation [created and managed by eksctl]",
+  "Resources": {
+    "Lock": {
+      "Type": "AWS::CloudFormation::WaitConditionHandle"
+    }
+  }
+}`
+
+// NewLockStackBackend returns a lock backend that uses a dedicated stack
+func (c *StackCollection) NewLockStackBackend() lock.Backend {
+	return &lockStackBackend{c: c}
+}
+
+func (c *StackCollection) makeLockStackName() string {
+	return "eksctl-" + c.spec.Metadata.Name + "-lock"
+}
+
+func (b *lockStackBackend) describe() (*Stack, error) {
+	s, err := b.c.DescribeStack(&Stack{StackName: aws.String(b.c.makeLockStackName())})
+	if err != nil {
+		if awsErr, ok := errors.Cause(err).(awserr.Error); ok && awsErr.Code() == "ValidationError" && strings.Contains(awsErr.Message(), "does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// wait polls the lock stack while it's in the given status; stack events are not
+// streamed, as the stack only has a single resource that doesn't create anything
+func (b *lockStackBackend) wait(status string) (*Stack, error) {
+	deadline := time.Now().Add(b.c.provider.WaitTimeout())
+	for {
+		s, err := b.describe()
+		if err != nil || s == nil || aws.StringValue(s.StackStatus) != status {
+			return s, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock stack %q in status %q", *s.StackName, status)
+		}
+		logger.Debug("waiting for lock stack %q in status %q", *s.StackName, status)
+		time.Sleep(lockStackPollInterval)
+	}
+}
+
+// Get returns the lock stored in the tag of the lock stack, if it exists
+func (b *lockStackBackend) Get() (*lock.Lock, error) {
+	// a stack that is being deleted must be gone before it can be created again
+	s, err := b.wait(cloudformation.StackStatusDeleteInProgress)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	return decodeLockTag(s)
+}
+
+// Create creates the lock stack, unless it exists already
+func (b *lockStackBackend) Create(l *lock.Lock) error {
+	value, err := encodeLockTag(l)
+	if err != nil {
+		return err
+	}
+	name := b.c.makeLockStackName()
+	input := &cloudformation.CreateStackInput{
+		StackName:    aws.String(name),
+		TemplateBody: aws.String(lockStackTemplate),
+		Tags: []*cloudformation.Tag{
+			newTag(api.ClusterNameTag, b.c.spec.Metadata.Name),
+			newTag(LockTag, value),
+		},
+	}
+	if cfnRole := b.c.provider.CloudFormationRoleARN(); cfnRole != "" {
+		input.SetRoleARN(cfnRole)
+	}
+	if _, err := b.c.provider.CloudFormation().CreateStack(input); err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudformation.ErrCodeAlreadyExistsException {
+			return lock.ErrAlreadyLocked
+		}
+		return errors.Wrapf(err, "creating lock stack %q", name)
+	}
+
+	s, err := b.wait(cloudformation.StackStatusCreateInProgress)
+	if err != nil {
+		return err
+	}
+	if s == nil || aws.StringValue(s.StackStatus) != cloudformation.StackStatusCreateComplete {
+		// don't leave behind a lock that nobody holds
+		if s != nil {
+			if err := b.deleteStack(s); err != nil {
+				logger.Warning(err.Error())
+			}
+		}
+		return fmt.Errorf("lock stack %q was not created", name)
+	}
+	return nil
+}
+
+// Delete deletes the lock stack, if it holds the given lock; it doesn't wait for
+// the stack to be deleted, as the next operation waits for it in Get
+func (b *lockStackBackend) Delete(l *lock.Lock) error {
+	s, err := b.describe()
+	if err != nil || s == nil {
+		return err
+	}
+	current, err := decodeLockTag(s)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.ID != l.ID {
+		return nil
+	}
+	return b.deleteStack(s)
+}
+
+func (b *lockStackBackend) deleteStack(s *Stack) error {
+	input := &cloudformation.DeleteStackInput{StackName: s.StackId}
+	if cfnRole := b.c.provider.CloudFormationRoleARN(); cfnRole != "" {
+		input.SetRoleARN(cfnRole)
+	}
+	if _, err := b.c.provider.CloudFormation().DeleteStack(input); err != nil {
+		return errors.Wrapf(err, "deleting lock stack %q", *s.StackName)
+	}
+	return nil
+}
+
+func encodeLockTag(l *lock.Lock) (string, error) {
+	trimmed := *l
+	for {
+		data, err := trimmed.Encode()
+		if err != nil {
+			return "", err
+		}
+		value := base64.RawURLEncoding.EncodeToString([]byte(data))
+		if len(value) <= maxLockTagLength {
+			return value, nil
+		}
+		if trimmed.Holder == "" && trimmed.Operation == "" {
+			return "", errors.New("lock is too large to be stored in a stack tag")
+		}
+		// shorten the descriptive fields until the lock fits
+		if len(trimmed.Holder) > 0 {
+			trimmed.Holder = trimmed.Holder[:len(trimmed.Holder)/2]
+		} else {
+			trimmed.Operation = trimmed.Operation[:len(trimmed.Operation)/2]
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package manager
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/lock"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection lock backend", func() {
+	var (
+		p       *mockprovider.MockProvider
+		backend lock.Backend
+		held    *lock.Lock
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		backend = NewStackCollection(p, cfg).NewLockBackend()
+
+		held = &lock.Lock{
+			ID:        "0123456789abcdef",
+			Holder:    "alice@workstation",
+			Operation: "scale nodegroup",
+			Acquired:  time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2019, 3, 1, 13, 0, 0, 0, time.UTC),
+		}
+	})
+
+	mockClusterStack := func(tags ...*cfn.Tag) {
+		p.MockCloudFormation().On("DescribeStacks", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
+			return *input.StackName == "eksctl-test-cluster-cluster"
+		})).Return(&cfn.DescribeStacksOutput{Stacks: []*cfn.Stack{{
+			StackName: aws.String("eksctl-test-cluster-cluster"),
+			Tags:      append([]*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster")}, tags...),
+		}}}, nil)
+	}
+
+	It("should encode locks so that these fit into a tag", func() {
+		value, err := encodeLockTag(held)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(value)).To(BeNumerically("<=", maxLockTagLength))
+
+		held.Holder = strings.Repeat("x", 300)
+		value, err = encodeLockTag(held)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(value)).To(BeNumerically("<=", maxLockTagLength))
+
+		data, err := base64.RawURLEncoding.DecodeString(value)
+		Expect(err).ToNot(HaveOccurred())
+		decoded, err := lock.Decode(string(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decoded.ID).To(Equal(held.ID))
+		Expect(decoded.Operation).To(Equal(held.Operation))
+	})
+
+	It("should return no lock when the stack is not tagged", func() {
+		mockClusterStack()
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(current).To(BeNil())
+	})
+
+	It("should read the lock from the tag and not create another", func() {
+		value, err := encodeLockTag(held)
+		Expect(err).ToNot(HaveOccurred())
+		mockClusterStack(newTag(LockTag, value))
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*current).To(Equal(*held))
+
+		Expect(backend.Create(&lock.Lock{ID: "other"})).To(Equal(lock.ErrAlreadyLocked))
+		Expect(backend.Delete(&lock.Lock{ID: "other"})).To(Succeed())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+	})
+
+	It("should ignore the lock and not lock the cluster when the stack cannot be updated", func() {
+		value, err := encodeLockTag(held)
+		Expect(err).ToNot(HaveOccurred())
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{Stacks: []*cfn.Stack{{
+			StackName:   aws.String("eksctl-test-cluster-cluster"),
+			StackStatus: aws.String(cfn.StackStatusDeleteFailed),
+			Tags:        []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster"), newTag(LockTag, value)},
+		}}}, nil)
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(current).To(BeNil())
+
+		Expect(backend.Create(&lock.Lock{ID: "other"})).To(Succeed())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+	})
+})
+
+var _ = Describe("StackCollection lock stack backend", func() {
+	var (
+		p       *mockprovider.MockProvider
+		backend lock.Backend
+		held    *lock.Lock
+		value   string
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		backend = NewStackCollection(p, cfg).NewLockStackBackend()
+
+		held = &lock.Lock{
+			ID:        "0123456789abcdef",
+			Holder:    "alice@workstation",
+			Operation: "scale nodegroup",
+			Acquired:  time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+			Expires:   time.Date(2019, 3, 1, 13, 0, 0, 0, time.UTC),
+		}
+		var err error
+		value, err = encodeLockTag(held)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	mockLockStack := func(status string) {
+		p.MockCloudFormation().On("DescribeStacks", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
+			return *input.StackName == "eksctl-test-cluster-lock"
+		})).Return(&cfn.DescribeStacksOutput{Stacks: []*cfn.Stack{{
+			StackName:   aws.String("eksctl-test-cluster-lock"),
+			StackId:     aws.String("eksctl-test-cluster-lock-id"),
+			StackStatus: aws.String(status),
+			Tags:        []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster"), newTag(LockTag, value)},
+		}}}, nil)
+	}
+
+	It("should return no lock when there is no lock stack", func() {
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(nil, awserr.New("ValidationError", "Stack with id eksctl-test-cluster-lock does not exist", nil))
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(current).To(BeNil())
+	})
+
+	It("should create the lock stack without updating the cluster stack", func() {
+		var input *cfn.CreateStackInput
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Run(func(args mock.Arguments) {
+			input = args[0].(*cfn.CreateStackInput)
+		}).Return(&cfn.CreateStackOutput{StackId: aws.String("eksctl-test-cluster-lock-id")}, nil)
+		mockLockStack(cfn.StackStatusCreateComplete)
+
+		Expect(backend.Create(held)).To(Succeed())
+		Expect(*input.StackName).To(Equal("eksctl-test-cluster-lock"))
+		Expect(input.Tags).To(ContainElement(newTag(LockTag, value)))
+		Expect(input.EnableTerminationProtection).To(BeNil())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*current).To(Equal(*held))
+	})
+
+	It("should not create another lock when the lock stack exists", func() {
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Return(nil, awserr.New(cfn.ErrCodeAlreadyExistsException, "Stack [eksctl-test-cluster-lock] already exists", nil))
+
+		Expect(backend.Create(&lock.Lock{ID: "other"})).To(Equal(lock.ErrAlreadyLocked))
+	})
+
+	It("should only delete the lock stack when it holds the given lock", func() {
+		mockLockStack(cfn.StackStatusCreateComplete)
+		p.MockCloudFormation().On("DeleteStack", mock.Anything).Return(&cfn.DeleteStackOutput{}, nil)
+
+		Expect(backend.Delete(&lock.Lock{ID: "other"})).To(Succeed())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 0)).To(BeTrue())
+
+		Expect(backend.Delete(held)).To(Succeed())
+		p.MockCloudFormation().AssertCalled(GinkgoT(), "DeleteStack", &cfn.DeleteStackInput{StackName: aws.String("eksctl-test-cluster-lock-id")})
+	})
+
+	It("should take over an expired lock once the lock stack is deleted", func() {
+		defer func(interval time.Duration) { lockStackPollInterval = interval }(lockStackPollInterval)
+		lockStackPollInterval = time.Millisecond
+
+		// the lock stack is deleted asynchronously, and it cannot be created
+		// again while it's still being deleted
+		status := cfn.StackStatusCreateComplete
+		describeCallsWhileDeleting := 0
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(func(*cfn.DescribeStacksInput) *cfn.DescribeStacksOutput {
+			if status == "" {
+				return nil
+			}
+			if status == cfn.StackStatusDeleteInProgress {
+				if describeCallsWhileDeleting++; describeCallsWhileDeleting == 2 {
+					status = ""
+				}
+			}
+			return &cfn.DescribeStacksOutput{Stacks: []*cfn.Stack{{
+				StackName:   aws.String("eksctl-test-cluster-lock"),
+				StackId:     aws.String("eksctl-test-cluster-lock-id"),
+				StackStatus: aws.String(status),
+				Tags:        []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster"), newTag(LockTag, value)},
+			}}}
+		}, func(*cfn.DescribeStacksInput) error {
+			if status == "" {
+				return awserr.New("ValidationError", "Stack with id eksctl-test-cluster-lock does not exist", nil)
+			}
+			return nil
+		})
+		p.MockCloudFormation().On("DeleteStack", mock.Anything).Run(func(mock.Arguments) {
+			status = cfn.StackStatusDeleteInProgress
+		}).Return(&cfn.DeleteStackOutput{}, nil)
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Return(func(input *cfn.CreateStackInput) *cfn.CreateStackOutput {
+			if status != "" {
+				return nil
+			}
+			status = cfn.StackStatusCreateComplete
+			value = *input.Tags[1].Value
+			return &cfn.CreateStackOutput{StackId: aws.String("eksctl-test-cluster-lock-id")}
+		}, func(*cfn.CreateStackInput) error {
+			if status != cfn.StackStatusCreateComplete {
+				return awserr.New(cfn.ErrCodeAlreadyExistsException, "Stack [eksctl-test-cluster-lock] already exists", nil)
+			}
+			return nil
+		})
+
+		locker := &lock.Locker{Backend: backend, TTL: time.Minute}
+		l, err := locker.Acquire("update cluster")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(l.ID).ToNot(Equal(held.ID))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)).To(BeTrue())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateStack", 1)).To(BeTrue())
+
+		current, err := backend.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(current.ID).To(Equal(l.ID))
+	})
+})
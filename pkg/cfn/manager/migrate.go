@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+// StackMigration describes how a stack created by an older version of eksctl
+// is brought up to the current format, and what couldn't be migrated
+type StackMigration struct {
+	StackName  string            `json:"stackName"`
+	AddTags    map[string]string `json:"addTags,omitempty"`
+	AddOutputs map[string]bool   `json:"addOutputs,omitempty"`
+	Problems   []string          `json:"problems,omitempty"`
+}
+
+// NeedsUpdate returns true when the stack has to be updated
+func (m *StackMigration) NeedsUpdate() bool {
+	return len(m.AddTags) > 0 || len(m.AddOutputs) > 0
+}
+
+// MigrateStacks adds current tags to all stacks of the cluster that only have legacy tags
+// or no tags at all, and it adds the feature outputs that nodegroup compatibility checks
+// rely on; it returns a migration for each stack, problems encountered in any of the stacks
+// are recorded in the migration, and don't stop other stacks from being migrated
+func (c *StackCollection) MigrateStacks(plan bool) ([]*StackMigration, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []*StackMigration{}
+	for _, s := range stacks {
+		m := &StackMigration{
+			StackName:  *s.StackName,
+			AddTags:    map[string]string{},
+			AddOutputs: map[string]bool{},
+		}
+		migrations = append(migrations, m)
+
+		if !c.StackStatusIsNotTransitional(s) {
+			m.Problems = append(m.Problems, fmt.Sprintf("stack is currently in %q state", *s.StackStatus))
+			continue
+		}
+
+		template, err := c.GetStackTemplate(*s.StackName)
+		if err != nil {
+			m.Problems = append(m.Problems, errors.Wrap(err, "getting stack template").Error())
+			continue
+		}
+
+		c.planStackMigration(s, template, m)
+		if !m.NeedsUpdate() {
+			continue
+		}
+
+		describeUpdate := fmt.Sprintf("updating stack %q to add tags %v and outputs %v", *s.StackName, m.AddTags, m.AddOutputs)
+		if plan {
+			logger.Info("(plan) %s", describeUpdate)
+			continue
+		}
+		if err := c.applyStackMigration(s, template, m, describeUpdate); err != nil {
+			m.Problems = append(m.Problems, err.Error())
+		}
+	}
+	return migrations, nil
+}
+
+func (c *StackCollection) planStackMigration(s *Stack, template string, m *StackMigration) {
+	clusterName := c.spec.Metadata.Name
+	if v := getClusterNameTag(s); v != "" && v != clusterName {
+		m.Problems = append(m.Problems, fmt.Sprintf("stack is tagged with cluster name %q", v))
+		return
+	}
+	tags := map[string]string{
+		api.ClusterNameTag:    clusterName,
+		api.OldClusterNameTag: clusterName,
+	}
+
+	if strings.HasPrefix(*s.StackName, "EKS-") {
+		m.Problems = append(m.Problems, "stack was created with the deprecated multi-stack layout, it can only be deleted with 'eksctl delete cluster'")
+	}
+
+	switch {
+	case strings.HasSuffix(*s.StackName, "-cluster"):
+		if !outputs.Exists(*s, outputs.ClusterSharedNodeSecurityGroup) {
+			m.Problems = append(m.Problems, fmt.Sprintf("shared node security group is missing, run 'eksctl update cluster --name=%s --region=%s' to add it",
+				clusterName, c.spec.Metadata.Region))
+		}
+	case c.GetNodeGroupName(s) != "":
+		name := c.GetNodeGroupName(s)
+		tags[api.NodeGroupNameTag] = name
+		tags[api.OldNodeGroupNameTag] = name
+		c.planNodeGroupFeatureOutputs(s, template, m)
+	}
+
+	for k, v := range tags {
+		if !hasTag(s, k) {
+			m.AddTags[k] = v
+		}
+	}
+}
+
+// planNodeGroupFeatureOutputs infers values of feature outputs from the template, as these
+// outputs were added in later versions of eksctl
+func (c *StackCollection) planNodeGroupFeatureOutputs(s *Stack, template string, m *StackMigration) {
+	resources := gjson.Get(template, resourcesRootPath)
+
+	if !outputs.Exists(*s, outputs.NodeGroupFeatureLocalSecurityGroup) {
+		m.AddOutputs[outputs.NodeGroupFeatureLocalSecurityGroup] = resources.Get("SG").Exists()
+	}
+
+	if !outputs.Exists(*s, outputs.NodeGroupFeatureSharedSecurityGroup) {
+		m.AddOutputs[outputs.NodeGroupFeatureSharedSecurityGroup] = strings.Contains(resources.Raw, "::"+outputs.ClusterSharedNodeSecurityGroup)
+	}
+
+	if !outputs.Exists(*s, outputs.NodeGroupFeaturePrivateNetworking) {
+		subnets := resources.Get("NodeGroup.Properties.VPCZoneIdentifier").Raw
+		switch {
+		case strings.Contains(subnets, "::"+outputs.ClusterSubnetsPrivate):
+			m.AddOutputs[outputs.NodeGroupFeaturePrivateNetworking] = true
+		case strings.Contains(subnets, "::"+outputs.ClusterSubnetsPublic),
+			strings.Contains(subnets, "::"+outputs.ClusterSubnetsPublicLegacy+`"`):
+			m.AddOutputs[outputs.NodeGroupFeaturePrivateNetworking] = false
+		default:
+			m.Problems = append(m.Problems, fmt.Sprintf("cannot infer whether private networking is used, output %q was not added", outputs.NodeGroupFeaturePrivateNetworking))
+		}
+	}
+}
+
+func (c *StackCollection) applyStackMigration(s *Stack, template string, m *StackMigration, describeUpdate string) error {
+	var err error
+	for k, v := range m.AddOutputs {
+		if template, err = sjson.Set(template, outputsRootPath+"."+k, map[string]interface{}{"Value": v}); err != nil {
+			return errors.Wrapf(err, "adding output %q to stack template", k)
+		}
+	}
+
+	var tags []*cloudformation.Tag
+	if len(m.AddTags) > 0 {
+		tags = append(tags, s.Tags...)
+		for k, v := range m.AddTags {
+			tags = append(tags, newTag(k, v))
+		}
+	}
+
+	return c.updateStack(*s.StackName, c.MakeChangeSetName("migrate-stack"), describeUpdate, []byte(template), nil, tags)
+}
+
+func hasTag(s *Stack, key string) bool {
+	for _, tag := range s.Tags {
+		if *tag.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package manager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection migration", func() {
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+	)
+
+	tag := func(k, v string) *cfn.Tag {
+		return &cfn.Tag{Key: aws.String(k), Value: aws.String(v)}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		sc = NewStackCollection(p, cfg)
+
+		stacks := []*cfn.Stack{
+			{
+				StackName:   aws.String("eksctl-test-cluster-cluster"),
+				StackStatus: aws.String(cfn.StackStatusCreateComplete),
+				Tags:        []*cfn.Tag{tag(api.OldClusterNameTag, "test-cluster")},
+			},
+			{
+				StackName:   aws.String("eksctl-test-cluster-nodegroup-0"),
+				StackStatus: aws.String(cfn.StackStatusCreateComplete),
+			},
+			{
+				StackName:   aws.String("eksctl-test-cluster-nodegroup-ng-1"),
+				StackStatus: aws.String(cfn.StackStatusUpdateComplete),
+				Tags: []*cfn.Tag{
+					tag(api.ClusterNameTag, "test-cluster"),
+					tag(api.OldClusterNameTag, "test-cluster"),
+					tag(api.OldNodeGroupIDTag, "ng-1"),
+				},
+			},
+			{
+				StackName:   aws.String("eksctl-test-cluster-nodegroup-ng-2"),
+				StackStatus: aws.String(cfn.StackStatusUpdateInProgress),
+			},
+		}
+		p.MockCloudFormation().On("DescribeStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(p *cfn.DescribeStacksOutput, last bool) (shouldContinue bool))
+			consume(&cfn.DescribeStacksOutput{Stacks: stacks}, true)
+		}).Return(nil)
+
+		templates := map[string]string{
+			"eksctl-test-cluster-cluster": `{"Resources":{"ControlPlane":{}}}`,
+			"eksctl-test-cluster-nodegroup-0": `{"Resources":{"NodeGroup":{"Properties":{` +
+				`"VPCZoneIdentifier":{"Fn::Split":[",",{"Fn::ImportValue":"eksctl-test-cluster-cluster::Subnets"}]}}}}}`,
+			"eksctl-test-cluster-nodegroup-ng-1": `{"Resources":{"SG":{},"NodeGroup":{"Properties":{` +
+				`"VPCZoneIdentifier":["subnet-1"]}},"NodeGroupLaunchTemplate":{"Properties":{"LaunchTemplateData":{` +
+				`"SecurityGroupIds":[{"Fn::ImportValue":"eksctl-test-cluster-cluster::SharedNodeSecurityGroup"}]}}}}}`,
+		}
+		for name, template := range templates {
+			name, template := name, template
+			p.MockCloudFormation().On("GetTemplate", mock.MatchedBy(func(input *cfn.GetTemplateInput) bool {
+				return *input.StackName == name
+			})).Return(&cfn.GetTemplateOutput{TemplateBody: aws.String(template)}, nil)
+		}
+	})
+
+	It("should plan migration of legacy tags and outputs", func() {
+		migrations, err := sc.MigrateStacks(true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(migrations).To(HaveLen(4))
+
+		cluster := migrations[0]
+		Expect(cluster.AddTags).To(Equal(map[string]string{api.ClusterNameTag: "test-cluster"}))
+		Expect(cluster.AddOutputs).To(BeEmpty())
+		Expect(cluster.Problems).To(ConsistOf(ContainSubstring("shared node security group is missing")))
+
+		legacyNodeGroup := migrations[1]
+		Expect(legacyNodeGroup.AddTags).To(Equal(map[string]string{
+			api.ClusterNameTag:      "test-cluster",
+			api.OldClusterNameTag:   "test-cluster",
+			api.NodeGroupNameTag:    "legacy-nodegroup-0",
+			api.OldNodeGroupNameTag: "legacy-nodegroup-0",
+		}))
+		Expect(legacyNodeGroup.AddOutputs).To(Equal(map[string]bool{
+			outputs.NodeGroupFeatureLocalSecurityGroup:  false,
+			outputs.NodeGroupFeatureSharedSecurityGroup: false,
+			outputs.NodeGroupFeaturePrivateNetworking:   false,
+		}))
+		Expect(legacyNodeGroup.Problems).To(BeEmpty())
+
+		nodeGroup := migrations[2]
+		Expect(nodeGroup.AddTags).To(Equal(map[string]string{
+			api.NodeGroupNameTag:    "ng-1",
+			api.OldNodeGroupNameTag: "ng-1",
+		}))
+		Expect(nodeGroup.AddOutputs).To(Equal(map[string]bool{
+			outputs.NodeGroupFeatureLocalSecurityGroup:  true,
+			outputs.NodeGroupFeatureSharedSecurityGroup: true,
+		}))
+		Expect(nodeGroup.Problems).To(ConsistOf(ContainSubstring("cannot infer whether private networking is used")))
+
+		Expect(migrations[3].NeedsUpdate()).To(BeFalse())
+		Expect(migrations[3].Problems).To(ConsistOf(`stack is currently in "UPDATE_IN_PROGRESS" state`))
+
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+	})
+})
@@ -3,7 +3,10 @@ package manager
 import (
 	"bytes"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kris-nova/logger"
@@ -15,6 +18,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 )
 
 const (
@@ -103,25 +107,32 @@ func (c *StackCollection) DescribeNodeGroupStacksAndResources() (map[string]Stac
 		return nil, err
 	}
 
+	var mutex sync.Mutex
 	allResources := make(map[string]StackInfo)
 
-	for _, s := range stacks {
+	err = forEachStack(stacks, func(_ int, s *Stack) error {
 		input := &cfn.DescribeStackResourcesInput{
 			StackName: s.StackName,
 		}
 		template, err := c.GetStackTemplate(*s.StackName)
 		if err != nil {
-			return nil, errors.Wrapf(err, "getting template for %q stack", *s.StackName)
+			return errors.Wrapf(err, "getting template for %q stack", *s.StackName)
 		}
 		resources, err := c.provider.CloudFormation().DescribeStackResources(input)
 		if err != nil {
-			return nil, errors.Wrapf(err, "getting all resources for %q stack", *s.StackName)
+			return errors.Wrapf(err, "getting all resources for %q stack", *s.StackName)
 		}
+		mutex.Lock()
+		defer mutex.Unlock()
 		allResources[c.GetNodeGroupName(s)] = StackInfo{
 			Resources: resources.StackResources,
 			Template:  &template,
 			Stack:     s,
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return allResources, nil
@@ -159,7 +170,7 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 
 	// Set the new values
 	newCapacity := fmt.Sprintf("%d", *ng.DesiredCapacity)
-	template, err = sjson.Set(template, desiredCapacityPath, newCapacity)
+	template, err = setNodeGroupSize(template, desiredCapacityPath, outputs.NodeGroupDesiredCapacity, newCapacity)
 	if err != nil {
 		return errors.Wrap(err, "setting desired capacity")
 	}
@@ -168,7 +179,7 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 	// If the desired number of nodes is less than the min then update the min
 	if int64(*ng.DesiredCapacity) < currentMinSize.Int() {
 		newMinSize := fmt.Sprintf("%d", *ng.DesiredCapacity)
-		template, err = sjson.Set(template, minSizePath, newMinSize)
+		template, err = setNodeGroupSize(template, minSizePath, outputs.NodeGroupMinSize, newMinSize)
 		if err != nil {
 			return errors.Wrap(err, "setting min size")
 		}
@@ -177,7 +188,7 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 	// If the desired number of nodes is greater than the max then update the max
 	if int64(*ng.DesiredCapacity) > currentMaxSize.Int() {
 		newMaxSize := fmt.Sprintf("%d", *ng.DesiredCapacity)
-		template, err = sjson.Set(template, maxSizePath, newMaxSize)
+		template, err = setNodeGroupSize(template, maxSizePath, outputs.NodeGroupMaxSize, newMaxSize)
 		if err != nil {
 			return errors.Wrap(err, "setting max size")
 		}
@@ -188,6 +199,91 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 	return c.UpdateStack(name, c.MakeChangeSetName("scale-nodegroup"), descriptionBuffer.String(), []byte(template), nil)
 }
 
+// setNodeGroupSize sets one of the sizes of the nodegroup in the template, along
+// with the matching output; a missing output is added when the stack has summary
+// outputs, as these used to omit the desired capacity when it wasn't set explicitly,
+// while stacks created by older versions of eksctl have no summary outputs at all
+func setNodeGroupSize(template, path, output, value string) (string, error) {
+	template, err := sjson.Set(template, path, value)
+	if err != nil {
+		return "", err
+	}
+	outputPath := outputsRootPath + "." + output + ".Value"
+	if !gjson.Get(template, outputPath).Exists() && !gjson.Get(template, outputsRootPath+"."+outputs.NodeGroupMinSize).Exists() {
+		return template, nil
+	}
+	return sjson.Set(template, outputPath, value)
+}
+
+// UpdateNodeGroupIngressRules updates SSH and custom ingress rules of the local
+// security group in an existing nodegroup stack, so that these match the given
+// nodegroup config; rules that are no longer configured are removed, it
+// returns true when the stack needed an update
+func (c *StackCollection) UpdateNodeGroupIngressRules(ng *api.NodeGroup, plan bool) (bool, error) {
+	name := c.makeNodeGroupStackName(ng.Name)
+
+	currentTemplate, err := c.GetStackTemplate(name)
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting stack template %s", name)
+	}
+	if !gjson.Get(currentTemplate, resourcesRootPath+".SG").Exists() {
+		return false, fmt.Errorf("nodegroup stack %q doesn't have a local security group", name)
+	}
+
+	newTemplate, err := builder.RenderNodeGroupIngressRules(c.spec, ng)
+	if err != nil {
+		return false, errors.Wrapf(err, "rendering ingress rules for %q stack", name)
+	}
+	logger.Debug("newTemplate = %s", newTemplate)
+
+	newResources := gjson.Get(string(newTemplate), resourcesRootPath)
+	currentResources := gjson.Get(currentTemplate, resourcesRootPath)
+	if !currentResources.IsObject() {
+		return false, fmt.Errorf("unexpected template format of the current stack ")
+	}
+
+	changedRules := []string{}
+	deleteRules := []string{}
+	currentResources.ForEach(func(k, _ gjson.Result) bool {
+		if builder.IsNodeGroupIngressRuleResource(k.String()) && !newResources.Get(k.String()).Exists() {
+			deleteRules = append(deleteRules, k.String())
+		}
+		return true
+	})
+	for _, k := range deleteRules {
+		if currentTemplate, err = sjson.Delete(currentTemplate, resourcesRootPath+"."+k); err != nil {
+			return false, errors.Wrapf(err, "removing %q from current stack template", k)
+		}
+	}
+
+	var iterErr error
+	newResources.ForEach(func(k, v gjson.Result) bool {
+		if reflect.DeepEqual(currentResources.Get(k.String()).Value(), v.Value()) {
+			return true
+		}
+		changedRules = append(changedRules, k.String())
+		currentTemplate, iterErr = sjson.Set(currentTemplate, resourcesRootPath+"."+k.String(), v.Value())
+		return iterErr == nil
+	})
+	if iterErr != nil {
+		return false, errors.Wrap(iterErr, "setting ingress rules in current stack template")
+	}
+
+	if len(changedRules) == 0 && len(deleteRules) == 0 {
+		logger.Success("ingress rules of nodegroup %q are up-to-date", ng.Name)
+		return false, nil
+	}
+
+	logger.Debug("currentTemplate = %s", currentTemplate)
+
+	describeUpdate := fmt.Sprintf("updating stack to set ingress rules %v and remove ingress rules %v", changedRules, deleteRules)
+	if plan {
+		logger.Info("(plan) %s", describeUpdate)
+		return true, nil
+	}
+	return true, c.UpdateStack(name, c.MakeChangeSetName("update-nodegroup-ingress"), describeUpdate, []byte(currentTemplate), nil)
+}
+
 // GetNodeGroupSummaries returns a list of summaries for the nodegroups of a cluster
 func (c *StackCollection) GetNodeGroupSummaries(name string) ([]*NodeGroupSummary, error) {
 	stacks, err := c.DescribeNodeGroupStacks()
@@ -195,52 +291,86 @@ func (c *StackCollection) GetNodeGroupSummaries(name string) ([]*NodeGroupSummar
 		return nil, errors.Wrap(err, "getting nodegroup stacks")
 	}
 
-	summaries := []*NodeGroupSummary{}
+	selected := []*Stack{}
 	for _, s := range stacks {
-		summary, err := c.mapStackToNodeGroupSummary(s)
-		if err != nil {
-			return nil, errors.Wrap(err, "mapping stack to nodegroup summary")
+		if name == "" || c.GetNodeGroupName(s) == name {
+			selected = append(selected, s)
 		}
+	}
 
-		if name == "" {
-			summaries = append(summaries, summary)
-		} else if summary.Name == name {
-			summaries = append(summaries, summary)
+	summaries := make([]*NodeGroupSummary, len(selected))
+	err = forEachStack(selected, func(i int, s *Stack) error {
+		summary, err := c.mapStackToNodeGroupSummary(s)
+		if err != nil {
+			return errors.Wrap(err, "mapping stack to nodegroup summary")
 		}
+		summaries[i] = summary
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return summaries, nil
 }
 
 func (c *StackCollection) mapStackToNodeGroupSummary(stack *Stack) (*NodeGroupSummary, error) {
-	template, err := c.GetStackTemplate(*stack.StackName)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error getting Cloudformation template for stack %s", *stack.StackName)
+	summary := &NodeGroupSummary{
+		StackName:    *stack.StackName,
+		Cluster:      getClusterNameTag(stack),
+		Name:         c.GetNodeGroupName(stack),
+		CreationTime: stack.CreationTime,
 	}
 
-	cluster := getClusterNameTag(stack)
-	name := c.GetNodeGroupName(stack)
-	maxSize := gjson.Get(template, maxSizePath)
-	minSize := gjson.Get(template, minSizePath)
-	desired := gjson.Get(template, desiredCapacityPath)
-	instanceType := gjson.Get(template, instanceTypePath)
-	imageID := gjson.Get(template, imageIDPath)
-
-	summary := &NodeGroupSummary{
-		StackName:       *stack.StackName,
-		Cluster:         cluster,
-		Name:            name,
-		MaxSize:         int(maxSize.Int()),
-		MinSize:         int(minSize.Int()),
-		DesiredCapacity: int(desired.Int()),
-		InstanceType:    instanceType.String(),
-		ImageID:         imageID.String(),
-		CreationTime:    stack.CreationTime,
+	if !outputs.Exists(*stack, outputs.NodeGroupMinSize) {
+		// stacks created by older versions of eksctl don't have summary outputs
+		if err := c.mapTemplateToNodeGroupSummary(stack, summary); err != nil {
+			return nil, err
+		}
+		return summary, nil
 	}
 
+	collectSize := func(size *int) outputs.Collector {
+		return func(v string) (err error) {
+			*size, err = strconv.Atoi(v)
+			return errors.Wrapf(err, "parsing nodegroup size from outputs of stack %q", *stack.StackName)
+		}
+	}
+	requiredCollectors := map[string]outputs.Collector{
+		outputs.NodeGroupMinSize: collectSize(&summary.MinSize),
+		outputs.NodeGroupMaxSize: collectSize(&summary.MaxSize),
+		outputs.NodeGroupInstanceType: func(v string) error {
+			summary.InstanceType = v
+			return nil
+		},
+		outputs.NodeGroupImageID: func(v string) error {
+			summary.ImageID = v
+			return nil
+		},
+	}
+	optionalCollectors := map[string]outputs.Collector{
+		outputs.NodeGroupDesiredCapacity: collectSize(&summary.DesiredCapacity),
+	}
+	if err := outputs.Collect(*stack, requiredCollectors, optionalCollectors); err != nil {
+		return nil, err
+	}
 	return summary, nil
 }
 
+func (c *StackCollection) mapTemplateToNodeGroupSummary(stack *Stack, summary *NodeGroupSummary) error {
+	template, err := c.GetStackTemplate(*stack.StackName)
+	if err != nil {
+		return errors.Wrapf(err, "error getting Cloudformation template for stack %s", *stack.StackName)
+	}
+
+	summary.MaxSize = int(gjson.Get(template, maxSizePath).Int())
+	summary.MinSize = int(gjson.Get(template, minSizePath).Int())
+	summary.DesiredCapacity = int(gjson.Get(template, desiredCapacityPath).Int())
+	summary.InstanceType = gjson.Get(template, instanceTypePath).String()
+	summary.ImageID = gjson.Get(template, imageIDPath).String()
+	return nil
+}
+
 // GetNodeGroupName will return nodegroup name based on tags
 func (*StackCollection) GetNodeGroupName(s *Stack) string {
 	for _, tag := range s.Tags {
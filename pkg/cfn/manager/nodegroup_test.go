@@ -7,7 +7,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/tidwall/gjson"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 )
 
@@ -83,6 +85,25 @@ var _ = Describe("StackCollection NodeGroup", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		It("should add a missing desired capacity output to stacks with summary outputs", func() {
+			template := `{
+				"Resources": {"NodeGroup": {"Properties": {"MinSize": "1", "MaxSize": "3"}}},
+				"Outputs": {"MinSize": {"Value": "1"}, "MaxSize": {"Value": "3"}}
+			}`
+			template, err := setNodeGroupSize(template, desiredCapacityPath, outputs.NodeGroupDesiredCapacity, "2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gjson.Get(template, desiredCapacityPath).String()).To(Equal("2"))
+			Expect(gjson.Get(template, "Outputs.DesiredCapacity.Value").String()).To(Equal("2"))
+		})
+
+		It("should not add outputs to stacks without summary outputs", func() {
+			template := `{"Resources": {"NodeGroup": {"Properties": {"MinSize": "1", "MaxSize": "3"}}}}`
+			template, err := setNodeGroupSize(template, desiredCapacityPath, outputs.NodeGroupDesiredCapacity, "2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gjson.Get(template, desiredCapacityPath).String()).To(Equal("2"))
+			Expect(gjson.Get(template, "Outputs").Exists()).To(BeFalse())
+		})
 	})
 
 	Describe("GetNodeGroupSummaries", func() {
@@ -110,38 +131,68 @@ var _ = Describe("StackCollection NodeGroup", func() {
 
 				p.MockCloudFormation().On("GetTemplate", mock.Anything).Return(nil, fmt.Errorf("GetTemplate failed"))
 
-				p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
-					consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
-					out := &cfn.ListStacksOutput{
-						StackSummaries: []*cfn.StackSummary{
+				p.MockCloudFormation().On("DescribeStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					consume := args[1].(func(p *cfn.DescribeStacksOutput, last bool) (shouldContinue bool))
+					consume(&cfn.DescribeStacksOutput{
+						Stacks: []*cfn.Stack{
 							{
-								StackName: aws.String("eksctl-test-cluster-nodegroup-12345"),
+								StackName:   aws.String("eksctl-test-cluster-nodegroup-12345"),
+								StackId:     aws.String("eksctl-test-cluster-nodegroup-12345-id"),
+								StackStatus: aws.String("CREATE_COMPLETE"),
+								Tags: []*cfn.Tag{
+									&cfn.Tag{
+										Key:   aws.String(api.NodeGroupNameTag),
+										Value: aws.String("12345"),
+									},
+								},
 							},
 						},
-					}
-					cont := consume(out, true)
-					if !cont {
-						panic("unexpected return value from the paging function: shouldContinue was false. It becomes false only when subsequent DescribeStacks call(s) fail, which isn't expected in this test scenario")
-					}
-				}).Return(nil)
-
-				p.MockCloudFormation().On("DescribeStacks", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
-					return input.StackName != nil && *input.StackName == "eksctl-test-cluster-nodegroup-12345"
-				})).Return(&cfn.DescribeStacksOutput{
-					Stacks: []*cfn.Stack{
-						{
-							StackName:   aws.String("eksctl-test-cluster-nodegroup-12345"),
-							StackId:     aws.String("eksctl-test-cluster-nodegroup-12345-id"),
-							StackStatus: aws.String("CREATE_COMPLETE"),
-							Tags: []*cfn.Tag{
-								&cfn.Tag{
-									Key:   aws.String(api.NodeGroupNameTag),
-									Value: aws.String("12345"),
+					}, false)
+					consume(&cfn.DescribeStacksOutput{
+						Stacks: []*cfn.Stack{
+							{
+								StackName:   aws.String("eksctl-test-cluster-nodegroup-67890"),
+								StackId:     aws.String("eksctl-test-cluster-nodegroup-67890-id"),
+								StackStatus: aws.String("UPDATE_COMPLETE"),
+								Tags: []*cfn.Tag{
+									&cfn.Tag{
+										Key:   aws.String(api.ClusterNameTag),
+										Value: aws.String("test-cluster"),
+									},
+									&cfn.Tag{
+										Key:   aws.String(api.NodeGroupNameTag),
+										Value: aws.String("67890"),
+									},
+								},
+								Outputs: []*cfn.Output{
+									{OutputKey: aws.String("MinSize"), OutputValue: aws.String("1")},
+									{OutputKey: aws.String("MaxSize"), OutputValue: aws.String("4")},
+									{OutputKey: aws.String("DesiredCapacity"), OutputValue: aws.String("2")},
+									{OutputKey: aws.String("InstanceType"), OutputValue: aws.String("m5.large")},
+									{OutputKey: aws.String("ImageID"), OutputValue: aws.String("ami-123")},
+								},
+							},
+							{
+								StackName:   aws.String("eksctl-test-cluster-nodegroup-other-nodegroup-ng"),
+								StackStatus: aws.String("CREATE_COMPLETE"),
+								Tags: []*cfn.Tag{
+									&cfn.Tag{
+										Key:   aws.String(api.ClusterNameTag),
+										Value: aws.String("test-cluster-nodegroup-other"),
+									},
+									&cfn.Tag{
+										Key:   aws.String(api.NodeGroupNameTag),
+										Value: aws.String("ng"),
+									},
 								},
 							},
+							{
+								StackName:   aws.String("eksctl-test-cluster-nodegroup-deleted"),
+								StackStatus: aws.String("DELETE_COMPLETE"),
+							},
 						},
-					},
-				}, nil)
+					}, true)
+				}).Return(nil)
 
 				p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(nil, fmt.Errorf("DescribeStacks failed"))
 			})
@@ -182,17 +233,32 @@ var _ = Describe("StackCollection NodeGroup", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 
-				It("should not have called AWS CloudFormation GetTemplate", func() {
+				It("should have called AWS CloudFormation GetTemplate only for the stack without outputs", func() {
 					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "GetTemplate", 1)).To(BeTrue())
 				})
 
-				It("should have called AWS CloudFormation DescribeStacks once", func() {
-					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacks", 1)).To(BeTrue())
+				It("should have described all stacks with a single paged request", func() {
+					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacksPages", 1)).To(BeTrue())
+					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacks", 0)).To(BeTrue())
+				})
+
+				It("should not fetch the same template again", func() {
+					_, err = sc.GetNodeGroupSummaries("")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "GetTemplate", 1)).To(BeTrue())
 				})
 
 				It("the output should equal the expectation", func() {
-					Expect(out).To(HaveLen(1))
+					Expect(out).To(HaveLen(2))
 					Expect(out[0].StackName).To(Equal("eksctl-test-cluster-nodegroup-12345"))
+					Expect(out[1].StackName).To(Equal("eksctl-test-cluster-nodegroup-67890"))
+					Expect(out[1].Name).To(Equal("67890"))
+					Expect(out[1].Cluster).To(Equal("test-cluster"))
+					Expect(out[1].MinSize).To(Equal(1))
+					Expect(out[1].MaxSize).To(Equal(4))
+					Expect(out[1].DesiredCapacity).To(Equal(2))
+					Expect(out[1].InstanceType).To(Equal("m5.large"))
+					Expect(out[1].ImageID).To(Equal("ami-123"))
 				})
 			})
 		})
@@ -0,0 +1,171 @@
+package manager
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// protectedResources are resources that cannot be replaced or deleted by
+// stack updates when the cluster is protected
+var protectedResources = []string{"ControlPlane", "VPC"}
+
+type stackPolicyStatement struct {
+	Effect    string      `json:"Effect"`
+	Action    interface{} `json:"Action"`
+	Principal string      `json:"Principal"`
+	Resource  interface{} `json:"Resource"`
+}
+
+type stackPolicy struct {
+	Statement []stackPolicyStatement `json:"Statement"`
+}
+
+// makeStackPolicy returns a stack policy that allows all updates, and when protect
+// is set, denies replacement and deletion of protected resources that are declared
+// in the given template; it returns an empty string when there is nothing to protect
+func makeStackPolicy(template string, protect bool) (string, error) {
+	resources := []string{}
+	for _, name := range protectedResources {
+		if gjson.Get(template, resourcesRootPath+"."+name).Exists() {
+			resources = append(resources, "LogicalResourceId/"+name)
+		}
+	}
+	if len(resources) == 0 {
+		return "", nil
+	}
+
+	policy := stackPolicy{}
+	if protect {
+		policy.Statement = append(policy.Statement, stackPolicyStatement{
+			Effect:    "Deny",
+			Action:    []string{"Update:Replace", "Update:Delete"},
+			Principal: "*",
+			Resource:  resources,
+		})
+	}
+	policy.Statement = append(policy.Statement, stackPolicyStatement{
+		Effect:    "Allow",
+		Action:    "Update:*",
+		Principal: "*",
+		Resource:  "*",
+	})
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return "", errors.Wrap(err, "rendering stack policy")
+	}
+	return string(data), nil
+}
+
+// DescribeProtectedStacks returns stacks of the cluster that have termination protection
+// enabled, unlike DescribeStacks it doesn't return an error when there are no stacks
+func (c *StackCollection) DescribeProtectedStacks() ([]*Stack, error) {
+	stacks, err := c.listClusterStacks()
+	if err != nil {
+		return nil, err
+	}
+	protected := []*Stack{}
+	for _, s := range stacks {
+		if *s.StackStatus != cloudformation.StackStatusDeleteComplete && aws.BoolValue(s.EnableTerminationProtection) {
+			protected = append(protected, s)
+		}
+	}
+	return protected, nil
+}
+
+// UpdateProtection enables or disables termination protection on all stacks of
+// the cluster, and updates the stack policy of the cluster stack accordingly;
+// it returns true when any changes were required
+func (c *StackCollection) UpdateProtection(protect, plan bool) (bool, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return false, err
+	}
+
+	updateRequired := false
+	for _, s := range stacks {
+		if *s.StackStatus == cloudformation.StackStatusDeleteComplete {
+			continue
+		}
+
+		if aws.BoolValue(s.EnableTerminationProtection) != protect {
+			updateRequired = true
+			if plan {
+				logger.Info("(plan) would set termination protection of stack %q to %v", *s.StackName, protect)
+			} else if err := c.setTerminationProtection(s, protect); err != nil {
+				return false, err
+			}
+		}
+
+		updated, err := c.updateStackPolicy(s, protect, plan)
+		if err != nil {
+			return false, err
+		}
+		updateRequired = updateRequired || updated
+	}
+
+	return updateRequired, nil
+}
+
+func (c *StackCollection) setTerminationProtection(s *Stack, protect bool) error {
+	input := &cloudformation.UpdateTerminationProtectionInput{
+		StackName:                   s.StackName,
+		EnableTerminationProtection: aws.Bool(protect),
+	}
+	if _, err := c.provider.CloudFormation().UpdateTerminationProtection(input); err != nil {
+		return errors.Wrapf(err, "updating termination protection of stack %q", *s.StackName)
+	}
+	logger.Info("set termination protection of stack %q to %v", *s.StackName, protect)
+	return nil
+}
+
+func (c *StackCollection) updateStackPolicy(s *Stack, protect, plan bool) (bool, error) {
+	if getClusterName(s) == "" {
+		return false, nil // only cluster stack has protected resources
+	}
+	template, err := c.GetStackTemplate(*s.StackName)
+	if err != nil {
+		return false, errors.Wrapf(err, "getting template of stack %q", *s.StackName)
+	}
+	policy, err := makeStackPolicy(template, protect)
+	if err != nil || policy == "" {
+		return false, err
+	}
+
+	current, err := c.provider.CloudFormation().GetStackPolicy(&cloudformation.GetStackPolicyInput{
+		StackName: s.StackName,
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "getting policy of stack %q", *s.StackName)
+	}
+	if aws.StringValue(current.StackPolicyBody) == policy {
+		return false, nil
+	}
+	// stack without a policy is not protected, there is no need to set a policy that allows all updates
+	if !protect && aws.StringValue(current.StackPolicyBody) == "" {
+		return false, nil
+	}
+
+	what := "protect"
+	if !protect {
+		what = "unprotect"
+	}
+	if plan {
+		logger.Info("(plan) would update policy of stack %q to %s %v", *s.StackName, what, protectedResources)
+		return true, nil
+	}
+	input := &cloudformation.SetStackPolicyInput{
+		StackName:       s.StackName,
+		StackPolicyBody: aws.String(policy),
+	}
+	if _, err := c.provider.CloudFormation().SetStackPolicy(input); err != nil {
+		return false, errors.Wrapf(err, "setting policy of stack %q", *s.StackName)
+	}
+	logger.Info("updated policy of stack %q to %s %v", *s.StackName, what, protectedResources)
+	return true, nil
+}
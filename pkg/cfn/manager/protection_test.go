@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection protection", func() {
+	const (
+		clusterStackName   = "eksctl-test-cluster-cluster"
+		nodeGroupStackName = "eksctl-test-cluster-nodegroup-ng-1"
+
+		clusterTemplate   = `{"Resources":{"ControlPlane":{},"VPC":{},"ServiceRole":{}}}`
+		nodeGroupTemplate = `{"Resources":{"NodeGroup":{}}}`
+
+		protectedPolicy = `{"Statement":[` +
+			`{"Effect":"Deny","Action":["Update:Replace","Update:Delete"],"Principal":"*","Resource":["LogicalResourceId/ControlPlane","LogicalResourceId/VPC"]},` +
+			`{"Effect":"Allow","Action":"Update:*","Principal":"*","Resource":"*"}]}`
+		unprotectedPolicy = `{"Statement":[{"Effect":"Allow","Action":"Update:*","Principal":"*","Resource":"*"}]}`
+	)
+
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+		sc  *StackCollection
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		sc = NewStackCollection(p, cfg)
+	})
+
+	It("should render stack policies", func() {
+		policy, err := makeStackPolicy(clusterTemplate, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(protectedPolicy))
+
+		policy, err = makeStackPolicy(clusterTemplate, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(Equal(unprotectedPolicy))
+
+		policy, err = makeStackPolicy(`{"Resources":{"ControlPlane":{}}}`, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(ContainSubstring(`"Resource":["LogicalResourceId/ControlPlane"]`))
+
+		policy, err = makeStackPolicy(nodeGroupTemplate, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(BeEmpty())
+	})
+
+	Context("when creating stacks", func() {
+		var input *cfn.CreateStackInput
+
+		BeforeEach(func() {
+			input = nil
+			p.MockCloudFormation().On("CreateStack", mock.Anything).Run(func(args mock.Arguments) {
+				input = args[0].(*cfn.CreateStackInput)
+			}).Return(&cfn.CreateStackOutput{StackId: aws.String("id")}, nil)
+		})
+
+		It("should not enable protection by default", func() {
+			Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(clusterStackName)}, []byte(clusterTemplate), nil, nil, false, false)).To(Succeed())
+			Expect(input.EnableTerminationProtection).To(BeNil())
+			Expect(input.StackPolicyBody).To(BeNil())
+		})
+
+		It("should enable protection when it's set", func() {
+			cfg.Metadata.Protection = true
+
+			Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(clusterStackName)}, []byte(clusterTemplate), nil, nil, false, false)).To(Succeed())
+			Expect(*input.EnableTerminationProtection).To(BeTrue())
+			Expect(*input.StackPolicyBody).To(Equal(protectedPolicy))
+
+			Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(nodeGroupStackName)}, []byte(nodeGroupTemplate), nil, nil, false, false)).To(Succeed())
+			Expect(*input.EnableTerminationProtection).To(BeTrue())
+			Expect(input.StackPolicyBody).To(BeNil())
+		})
+	})
+
+	Context("with existing stacks", func() {
+		var stackPolicy string
+
+		BeforeEach(func() {
+			stackPolicy = ""
+
+			stacks := map[string]bool{
+				clusterStackName:   false,
+				nodeGroupStackName: true,
+			}
+
+			p.MockCloudFormation().On("DescribeStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				consume := args[1].(func(p *cfn.DescribeStacksOutput, last bool) (shouldContinue bool))
+				out := &cfn.DescribeStacksOutput{}
+				for _, name := range []string{clusterStackName, nodeGroupStackName} {
+					out.Stacks = append(out.Stacks, &cfn.Stack{
+						StackName:                   aws.String(name),
+						StackStatus:                 aws.String(cfn.StackStatusCreateComplete),
+						EnableTerminationProtection: aws.Bool(stacks[name]),
+						Tags: []*cfn.Tag{
+							{Key: aws.String(api.ClusterNameTag), Value: aws.String("test-cluster")},
+						},
+					})
+				}
+				consume(out, true)
+			}).Return(nil)
+
+			p.MockCloudFormation().On("GetTemplate", mock.MatchedBy(func(input *cfn.GetTemplateInput) bool {
+				return *input.StackName == clusterStackName
+			})).Return(&cfn.GetTemplateOutput{TemplateBody: aws.String(clusterTemplate)}, nil)
+
+			p.MockCloudFormation().On("GetStackPolicy", mock.Anything).Return(func(*cfn.GetStackPolicyInput) *cfn.GetStackPolicyOutput {
+				if stackPolicy == "" {
+					return &cfn.GetStackPolicyOutput{}
+				}
+				return &cfn.GetStackPolicyOutput{StackPolicyBody: aws.String(stackPolicy)}
+			}, nil)
+
+			p.MockCloudFormation().On("UpdateTerminationProtection", mock.Anything).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+			p.MockCloudFormation().On("SetStackPolicy", mock.Anything).Return(&cfn.SetStackPolicyOutput{}, nil)
+		})
+
+		It("should describe protected stacks", func() {
+			stacks, err := sc.DescribeProtectedStacks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stacks).To(HaveLen(1))
+			Expect(*stacks[0].StackName).To(Equal(nodeGroupStackName))
+		})
+
+		It("should only plan changes in plan mode", func() {
+			updateRequired, err := sc.UpdateProtection(true, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updateRequired).To(BeTrue())
+
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "SetStackPolicy", 0)).To(BeTrue())
+		})
+
+		It("should enable protection where it's missing", func() {
+			updateRequired, err := sc.UpdateProtection(true, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updateRequired).To(BeTrue())
+
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "UpdateTerminationProtection", &cfn.UpdateTerminationProtectionInput{
+				StackName:                   aws.String(clusterStackName),
+				EnableTerminationProtection: aws.Bool(true),
+			})
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "SetStackPolicy", &cfn.SetStackPolicyInput{
+				StackName:       aws.String(clusterStackName),
+				StackPolicyBody: aws.String(protectedPolicy),
+			})
+		})
+
+		It("should disable protection and replace the stack policy", func() {
+			stackPolicy = protectedPolicy
+
+			updateRequired, err := sc.UpdateProtection(false, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updateRequired).To(BeTrue())
+
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "UpdateTerminationProtection", &cfn.UpdateTerminationProtectionInput{
+				StackName:                   aws.String(nodeGroupStackName),
+				EnableTerminationProtection: aws.Bool(false),
+			})
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "SetStackPolicy", &cfn.SetStackPolicyInput{
+				StackName:       aws.String(clusterStackName),
+				StackPolicyBody: aws.String(unprotectedPolicy),
+			})
+		})
+
+		It("should not set a stack policy when disabling protection of a stack without one", func() {
+			updateRequired, err := sc.UpdateProtection(false, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updateRequired).To(BeTrue())
+
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "SetStackPolicy", 0)).To(BeTrue())
+		})
+	})
+})
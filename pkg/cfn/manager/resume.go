@@ -0,0 +1,169 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+// ExistingStacks holds the stacks of a cluster that was partially created
+type ExistingStacks struct {
+	Cluster    *Stack
+	NodeGroups map[string]*Stack
+}
+
+// DescribeExistingStacks returns all stacks of the cluster that were not
+// deleted yet, it doesn't error when there are none
+func (c *StackCollection) DescribeExistingStacks() (*ExistingStacks, error) {
+	stacks, err := c.listClusterStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := &ExistingStacks{NodeGroups: map[string]*Stack{}}
+	for _, s := range stacks {
+		if *s.StackStatus == cloudformation.StackStatusDeleteComplete {
+			continue
+		}
+		if getClusterName(s) != "" {
+			existing.Cluster = s
+			continue
+		}
+		if name := c.GetNodeGroupName(s); name != "" {
+			existing.NodeGroups[name] = s
+		}
+	}
+	return existing, nil
+}
+
+// StackIsHealthy returns true when stack was created successfully
+func (*StackCollection) StackIsHealthy(s *Stack) bool {
+	switch *s.StackStatus {
+	case cloudformation.StackStatusCreateComplete,
+		cloudformation.StackStatusUpdateComplete,
+		cloudformation.StackStatusUpdateRollbackComplete:
+		return true
+	}
+	return false
+}
+
+// StackNeedsRecreation returns true when stack creation had failed,
+// and the stack has to be deleted before it can be created again
+func (*StackCollection) StackNeedsRecreation(s *Stack) bool {
+	switch *s.StackStatus {
+	case cloudformation.StackStatusCreateFailed,
+		cloudformation.StackStatusRollbackComplete:
+		return true
+	}
+	return false
+}
+
+// NewTasksToResumeClusterWithNodeGroups defines tasks required to complete creation
+// of a cluster along with some nodegroups, stacks that were created successfully
+// are kept, failed stacks are deleted and created again, and missing stacks are
+// created; see CreateAllNodeGroups for how onlyNodeGroupSubset works
+func (c *StackCollection) NewTasksToResumeClusterWithNodeGroups(existing *ExistingStacks, onlyNodeGroupSubset sets.String) (*TaskTree, error) {
+	tasks := &TaskTree{Parallel: false}
+
+	clusterTasks, err := c.newTasksToResumeStack(existing.Cluster, fmt.Sprintf("cluster control plane %q", c.spec.Metadata.Name),
+		&taskWithoutParams{
+			info: fmt.Sprintf("create cluster control plane %q", c.spec.Metadata.Name),
+			call: c.createClusterTask,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if clusterTasks.Len() > 0 {
+		clusterTasks.IsSubTask = true
+		tasks.Append(clusterTasks)
+	}
+
+	nodeGroupTasks := &TaskTree{Parallel: true, IsSubTask: true}
+	for i := range c.spec.NodeGroups {
+		ng := c.spec.NodeGroups[i]
+		if onlyNodeGroupSubset != nil && !onlyNodeGroupSubset.Has(ng.Name) {
+			continue
+		}
+		ngTasks, err := c.newTasksToResumeStack(existing.NodeGroups[ng.Name], fmt.Sprintf("nodegroup %q", ng.Name),
+			&taskWithNodeGroupSpec{
+				info:      fmt.Sprintf("create nodegroup %q", ng.Name),
+				nodeGroup: ng,
+				call:      c.createNodeGroupTask,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if ngTasks.Len() > 0 {
+			ngTasks.IsSubTask = true
+			nodeGroupTasks.Append(ngTasks)
+		}
+	}
+	if nodeGroupTasks.Len() > 0 {
+		tasks.Append(nodeGroupTasks)
+	}
+
+	return tasks, nil
+}
+
+func (c *StackCollection) newTasksToResumeStack(s *Stack, what string, create Task) (*TaskTree, error) {
+	tasks := &TaskTree{Parallel: false}
+	switch {
+	case s == nil:
+		tasks.Append(create)
+	case c.StackIsHealthy(s):
+		logger.Info("%s was already created, stack %q will be kept", what, *s.StackName)
+	case c.StackNeedsRecreation(s):
+		logger.Info("%s had failed to be created, stack %q is in %q state and will be re-created", what, *s.StackName, *s.StackStatus)
+		tasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete failed %s", what),
+			stack: s,
+			call:  c.deleteFailedStack,
+		})
+		tasks.Append(create)
+	default:
+		return nil, fmt.Errorf("cannot resume, as stack %q for %s is in %q state", *s.StackName, what, *s.StackStatus)
+	}
+	return tasks, nil
+}
+
+// deleteFailedStack deletes a stack that had failed to be created, termination
+// protection is disabled first, as there is nothing to protect in a failed stack
+// and CloudFormation refuses to delete a protected stack
+func (c *StackCollection) deleteFailedStack(s *Stack, errs chan error) error {
+	if aws.BoolValue(s.EnableTerminationProtection) {
+		if err := c.setTerminationProtection(s, false); err != nil {
+			return err
+		}
+	}
+	return c.DeleteStackBySpecSync(s, errs)
+}
+
+// UseExistingNodeGroupStack loads outputs of a nodegroup stack that was
+// already created into the given nodegroup config
+func (c *StackCollection) UseExistingNodeGroupStack(ng *api.NodeGroup, s *Stack) error {
+	if ng.IAM == nil {
+		ng.IAM = &api.NodeGroupIAM{}
+	}
+	requiredCollectors := map[string]outputs.Collector{
+		outputs.NodeGroupInstanceRoleARN: func(v string) error {
+			ng.IAM.InstanceRoleARN = v
+			return nil
+		},
+	}
+	optionalCollectors := map[string]outputs.Collector{
+		outputs.NodeGroupInstanceProfileARN: func(v string) error {
+			ng.IAM.InstanceProfileARN = v
+			return nil
+		},
+	}
+	return outputs.Collect(*s, requiredCollectors, optionalCollectors)
+}
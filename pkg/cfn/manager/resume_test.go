@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection resume", func() {
+	var (
+		cfg          *api.ClusterConfig
+		p            *mockprovider.MockProvider
+		stackManager *StackCollection
+		existing     *ExistingStacks
+	)
+
+	newStack := func(name, status string) *Stack {
+		return &Stack{
+			StackName:   aws.String(name),
+			StackStatus: aws.String(status),
+		}
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		for _, name := range []string{"ng-1", "ng-2", "ng-3"} {
+			ng := cfg.NewNodeGroup()
+			ng.Name = name
+		}
+		p = mockprovider.NewMockProvider()
+		stackManager = NewStackCollection(p, cfg)
+		existing = &ExistingStacks{NodeGroups: map[string]*Stack{}}
+	})
+
+	It("should create everything when there are no stacks", func() {
+		tasks, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks.Describe()).To(Equal(`2 sequential tasks: { create cluster control plane "test-cluster", 3 parallel sub-tasks: { create nodegroup "ng-1", create nodegroup "ng-2", create nodegroup "ng-3" } }`))
+	})
+
+	It("should keep healthy stacks, re-create failed ones and create missing ones", func() {
+		existing.Cluster = newStack("eksctl-test-cluster-cluster", cfn.StackStatusCreateComplete)
+		existing.NodeGroups["ng-1"] = newStack("eksctl-test-cluster-nodegroup-ng-1", cfn.StackStatusCreateComplete)
+		existing.NodeGroups["ng-2"] = newStack("eksctl-test-cluster-nodegroup-ng-2", cfn.StackStatusRollbackComplete)
+
+		tasks, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks.Describe()).To(Equal(`1 task: { 2 parallel sub-tasks: { 2 sequential sub-tasks: { delete failed nodegroup "ng-2", create nodegroup "ng-2" }, create nodegroup "ng-3" } }`))
+	})
+
+	It("should re-create failed cluster stack", func() {
+		existing.Cluster = newStack("eksctl-test-cluster-cluster", cfn.StackStatusCreateFailed)
+
+		tasks, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks.Describe()).To(HavePrefix(`2 sequential tasks: { 2 sequential sub-tasks: { delete failed cluster control plane "test-cluster", create cluster control plane "test-cluster" }, `))
+	})
+
+	It("should refuse to resume when a stack is in progress", func() {
+		existing.Cluster = newStack("eksctl-test-cluster-cluster", cfn.StackStatusCreateComplete)
+		existing.NodeGroups["ng-3"] = newStack("eksctl-test-cluster-nodegroup-ng-3", cfn.StackStatusCreateInProgress)
+
+		_, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, nil)
+		Expect(err).To(MatchError(`cannot resume, as stack "eksctl-test-cluster-nodegroup-ng-3" for nodegroup "ng-3" is in "CREATE_IN_PROGRESS" state`))
+	})
+
+	Context("when a failed stack has termination protection", func() {
+		var failed *Stack
+
+		BeforeEach(func() {
+			existing.Cluster = newStack("eksctl-test-cluster-cluster", cfn.StackStatusCreateComplete)
+			failed = newStack("eksctl-test-cluster-nodegroup-ng-1", cfn.StackStatusRollbackComplete)
+			failed.StackId = aws.String("eksctl-test-cluster-nodegroup-ng-1-id")
+			failed.Tags = []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster")}
+			existing.NodeGroups["ng-1"] = failed
+
+			p.MockCloudFormation().On("UpdateTerminationProtection", mock.Anything).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+			// stop before waiting for the stack to be deleted
+			p.MockCloudFormation().On("DeleteStack", mock.Anything).Return(nil, awserr.New("ValidationError", "stop here", nil))
+		})
+
+		resume := func() []error {
+			tasks, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, sets.NewString("ng-1"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks.Describe()).To(Equal(`1 task: { 2 sequential sub-tasks: { delete failed nodegroup "ng-1", create nodegroup "ng-1" } }`))
+			return tasks.DoAllSync()
+		}
+
+		It("should disable termination protection before deleting it", func() {
+			failed.EnableTerminationProtection = aws.Bool(true)
+
+			errs := resume()
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("stop here"))
+
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "UpdateTerminationProtection", &cfn.UpdateTerminationProtectionInput{
+				StackName:                   aws.String("eksctl-test-cluster-nodegroup-ng-1"),
+				EnableTerminationProtection: aws.Bool(false),
+			})
+			p.MockCloudFormation().AssertCalled(GinkgoT(), "DeleteStack", &cfn.DeleteStackInput{
+				StackName: aws.String("eksctl-test-cluster-nodegroup-ng-1-id"),
+			})
+		})
+
+		It("should not update termination protection of a stack without it", func() {
+			errs := resume()
+			Expect(errs).To(HaveLen(1))
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)).To(BeTrue())
+		})
+	})
+
+	It("should load outputs of existing nodegroup stack", func() {
+		s := newStack("eksctl-test-cluster-nodegroup-ng-1", cfn.StackStatusCreateComplete)
+		s.Outputs = []*cfn.Output{{
+			OutputKey:   aws.String(outputs.NodeGroupInstanceRoleARN),
+			OutputValue: aws.String("arn:aws:iam::123456789012:role/ng-1"),
+		}}
+		ng := cfg.NodeGroups[0]
+		Expect(stackManager.UseExistingNodeGroupStack(ng, s)).To(Succeed())
+		Expect(ng.IAM.InstanceRoleARN).To(Equal("arn:aws:iam::123456789012:role/ng-1"))
+	})
+})
@@ -1,9 +1,11 @@
 package manager
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
-	"sync"
 
 	"github.com/kris-nova/logger"
 
@@ -16,12 +18,18 @@ type Task interface {
 	Describe() string
 }
 
-// TaskTree wraps a set of tasks
+// TaskTree wraps a set of tasks, these run either sequentially or in parallel;
+// additionally, a task may depend on other tasks of the same tree, in which case
+// it will only be started once all of its dependencies have completed successfully
 type TaskTree struct {
-	tasks     []Task
-	Parallel  bool
-	PlanMode  bool
-	IsSubTask bool
+	tasks        []Task
+	dependencies map[Task][]Task
+	Parallel     bool
+	PlanMode     bool
+	IsSubTask    bool
+	// MaxConcurrency limits how many tasks of the tree may run at
+	// the same time, there is no limit when it's not set
+	MaxConcurrency int
 }
 
 // Append new tasks to the set
@@ -29,6 +37,30 @@ func (t *TaskTree) Append(task ...Task) {
 	t.tasks = append(t.tasks, task...)
 }
 
+// AppendWithDependencies appends a task that will only be started once
+// all of the given tasks, which must belong to this set, have completed
+// successfully
+func (t *TaskTree) AppendWithDependencies(task Task, dependencies ...Task) {
+	t.Append(task)
+	if len(dependencies) == 0 {
+		return
+	}
+	if t.dependencies == nil {
+		t.dependencies = map[Task][]Task{}
+	}
+	t.dependencies[task] = append(t.dependencies[task], dependencies...)
+}
+
+// LimitConcurrency sets MaxConcurrency of the set and all of its sub-tasks
+func (t *TaskTree) LimitConcurrency(max int) {
+	t.MaxConcurrency = max
+	for _, task := range t.tasks {
+		if subTree, ok := task.(*TaskTree); ok {
+			subTree.LimitConcurrency(max)
+		}
+	}
+}
+
 // Len returns number of tasks in the set
 func (t *TaskTree) Len() int {
 	if t == nil {
@@ -43,27 +75,18 @@ func (t *TaskTree) Describe() string {
 	for _, task := range t.tasks {
 		descriptions = append(descriptions, task.Describe())
 	}
-	mode := "sequential"
-	if t.Parallel {
-		mode = "parallel"
-	}
 	count := len(descriptions)
 	var msg string
-	noun := "task"
-	if t.IsSubTask {
-		noun = "sub-task"
-	}
 	switch count {
 	case 0:
 		msg = "no tasks"
 	case 1:
-		msg = fmt.Sprintf("1 %s: { %s }", noun, descriptions[0])
+		msg = fmt.Sprintf("%s: { %s }", t.summary(), descriptions[0])
 		if t.IsSubTask {
 			msg = descriptions[0] // simple description for single sub-task
 		}
 	default:
-		noun += "s"
-		msg = fmt.Sprintf("%d %s %s: { %s }", count, mode, noun, strings.Join(descriptions, ", "))
+		msg = fmt.Sprintf("%s: { %s }", t.summary(), strings.Join(descriptions, ", "))
 	}
 	if t.PlanMode {
 		return "(plan) " + msg
@@ -71,49 +94,187 @@ func (t *TaskTree) Describe() string {
 	return msg
 }
 
+// summary returns e.g. "2 parallel sub-tasks" or "1 task"
+func (t *TaskTree) summary() string {
+	count := len(t.tasks)
+	noun := "task"
+	if t.IsSubTask {
+		noun = "sub-task"
+	}
+	switch count {
+	case 0:
+		return "no tasks"
+	case 1:
+		return fmt.Sprintf("1 %s", noun)
+	}
+	mode := "sequential"
+	if t.Parallel {
+		mode = "parallel"
+	}
+	return fmt.Sprintf("%d %s %ss", count, mode, noun)
+}
+
+// RenderGraph describes the set in a multi-line format, which shows how
+// the tasks are nested, as well as dependencies and concurrency limits
+func (t *TaskTree) RenderGraph() string {
+	lines := []string{}
+	t.renderGraph(&lines, "")
+	if t.PlanMode {
+		lines[0] = "(plan) " + lines[0]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (t *TaskTree) renderGraph(lines *[]string, indent string) {
+	header := t.summary()
+	if t.MaxConcurrency > 0 && t.Parallel && t.Len() > 1 {
+		header += fmt.Sprintf(" (at most %d at a time)", t.MaxConcurrency)
+	}
+	*lines = append(*lines, indent+header)
+	for _, task := range t.tasks {
+		t.renderGraphTask(task, lines, indent+"  ")
+	}
+}
+
+func (t *TaskTree) renderGraphTask(task Task, lines *[]string, indent string) {
+	first := len(*lines)
+	subTree, ok := task.(*TaskTree)
+	switch {
+	case !ok:
+		*lines = append(*lines, indent+task.Describe())
+	case subTree.IsSubTask && subTree.Len() == 1:
+		// same as in Describe, a single sub-task is shown on its own
+		subTree.renderGraphTask(subTree.tasks[0], lines, indent)
+	default:
+		subTree.renderGraph(lines, indent)
+	}
+
+	if dependencies := t.dependencies[task]; len(dependencies) > 0 {
+		descriptions := []string{}
+		for _, dependency := range dependencies {
+			descriptions = append(descriptions, dependency.Describe())
+		}
+		(*lines)[first] += fmt.Sprintf(" (after: %s)", strings.Join(descriptions, ", "))
+	}
+}
+
+// Validate checks that dependencies of each of the tasks in the set
+// and its sub-tasks belong to the same set, and that there are no cycles
+func (t *TaskTree) Validate() error {
+	if _, err := t.graph(); err != nil {
+		return err
+	}
+	for _, task := range t.tasks {
+		if subTree, ok := task.(*TaskTree); ok {
+			if err := subTree.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// graph returns indices of the tasks that each of the tasks depends on, in
+// a sequential set every task implicitly depends on the preceding one
+func (t *TaskTree) graph() ([][]int, error) {
+	index := make(map[Task]int, len(t.tasks))
+	for i, task := range t.tasks {
+		index[task] = i
+	}
+
+	dependencies := make([][]int, len(t.tasks))
+	for i, task := range t.tasks {
+		if !t.Parallel && i > 0 {
+			dependencies[i] = append(dependencies[i], i-1)
+		}
+		for _, dependency := range t.dependencies[task] {
+			j, ok := index[dependency]
+			if !ok {
+				return nil, fmt.Errorf("task %q depends on %q, which is not in the same set of tasks", task.Describe(), dependency.Describe())
+			}
+			dependencies[i] = append(dependencies[i], j)
+		}
+	}
+
+	// check for cycles by removing tasks without dependencies until none are left
+	pending := make([]int, len(t.tasks))
+	dependents := make([][]int, len(t.tasks))
+	ready := []int{}
+	for i := range dependencies {
+		pending[i] = len(dependencies[i])
+		for _, j := range dependencies[i] {
+			dependents[j] = append(dependents[j], i)
+		}
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	visited := 0
+	for ; len(ready) > 0; visited++ {
+		i := ready[0]
+		ready = ready[1:]
+		for _, j := range dependents[i] {
+			if pending[j]--; pending[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if visited != len(t.tasks) {
+		return nil, fmt.Errorf("dependencies of tasks in %q form a cycle", t.Describe())
+	}
+
+	return dependencies, nil
+}
+
 // Do will run through the set in the backround, it may return an error immediately,
 // or eventually write to the errs channel; it will close the channel once all tasks
 // are completed
 func (t *TaskTree) Do(allErrs chan error) error {
+	return t.DoWithContext(context.Background(), allErrs)
+}
+
+// DoWithContext is the same as Do, but no new tasks will be started once ctx
+// is cancelled, while the tasks that were already started run to completion
+func (t *TaskTree) DoWithContext(ctx context.Context, allErrs chan error) error {
 	if t.Len() == 0 || t.PlanMode {
 		logger.Debug("no actual tasks")
 		close(allErrs)
 		return nil
 	}
 
-	errs := make(chan error)
-
-	if t.Parallel {
-		go doParallelTasks(errs, t.tasks)
-	} else {
-		go doSequentialTasks(errs, t.tasks)
+	if err := t.Validate(); err != nil {
+		close(allErrs)
+		return err
 	}
 
-	go func() {
-		defer close(allErrs)
-		for err := range errs {
-			allErrs <- err
-		}
-	}()
+	go t.doAll(ctx, allErrs)
 
 	return nil
 }
 
 // DoAllSync will run through the set in the foregounds and return all the errors
-// in a slice
+// in a slice; if an interrupt signal is received, no new tasks will be started
 func (t *TaskTree) DoAllSync() []error {
+	ctx, stop := contextWithInterrupt(context.Background())
+	defer stop()
+	return t.DoAllSyncWithContext(ctx)
+}
+
+// DoAllSyncWithContext is the same as DoAllSync, but no new tasks will be started
+// once ctx is cancelled, while the tasks that were already started run to completion
+func (t *TaskTree) DoAllSyncWithContext(ctx context.Context) []error {
 	if t.Len() == 0 || t.PlanMode {
 		logger.Debug("no actual tasks")
 		return nil
 	}
 
+	if err := t.Validate(); err != nil {
+		return []error{err}
+	}
+
 	errs := make(chan error)
 
-	if t.Parallel {
-		go doParallelTasks(errs, t.tasks)
-	} else {
-		go doSequentialTasks(errs, t.tasks)
-	}
+	go t.doAll(ctx, errs)
 
 	allErrs := []error{}
 	for err := range errs {
@@ -122,6 +283,155 @@ func (t *TaskTree) DoAllSync() []error {
 	return allErrs
 }
 
+func (t *TaskTree) doAll(ctx context.Context, allErrs chan error) {
+	defer close(allErrs)
+	if notStarted := t.run(ctx, allErrs); notStarted > 0 {
+		if ctx.Err() != nil {
+			allErrs <- fmt.Errorf("%d task(s) were not started, as the operation was cancelled", notStarted)
+			return
+		}
+		logger.Warning("%d task(s) were not started, as an earlier task has failed", notStarted)
+	}
+}
+
+// run starts each of the tasks as soon as all of its dependencies have completed, while
+// respecting MaxConcurrency; errors are written to allErrs as they occur, and once any
+// of the tasks fails or ctx is cancelled, it waits for the running tasks to complete
+// without starting any new ones; it returns how many tasks were not started
+func (t *TaskTree) run(ctx context.Context, allErrs chan error) int {
+	dependencies, err := t.graph()
+	if err != nil {
+		allErrs <- err
+		return t.Len()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pending := make([]int, len(t.tasks))
+	dependents := make([][]int, len(t.tasks))
+	ready := []int{}
+	for i := range dependencies {
+		pending[i] = len(dependencies[i])
+		for _, j := range dependencies[i] {
+			dependents[j] = append(dependents[j], i)
+		}
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	maxConcurrency := t.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(t.tasks)
+	}
+
+	type result struct {
+		index, notStarted int
+		failed            bool
+	}
+	results := make(chan result)
+
+	running, started, notStarted := 0, 0, 0
+	cancelled := ctx.Done()
+	for {
+		// all tasks that are ready get started in one go, so that the outcome
+		// doesn't depend on how quickly any of these fails
+		for cancelled != nil && len(ready) > 0 && running < maxConcurrency {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			started++
+			go func(i int) {
+				failed, notStarted := doTask(ctx, t.tasks[i], allErrs)
+				results <- result{index: i, notStarted: notStarted, failed: failed}
+			}(i)
+		}
+		if running == 0 {
+			break
+		}
+		logger.Debug("waiting for %d of %d tasks to complete", running, len(t.tasks))
+		select {
+		case r := <-results:
+			running--
+			notStarted += r.notStarted
+			if r.failed {
+				cancel()
+				cancelled = nil
+				continue
+			}
+			for _, j := range dependents[r.index] {
+				if pending[j]--; pending[j] == 0 {
+					ready = append(ready, j)
+				}
+			}
+		case <-cancelled:
+			cancelled = nil
+		}
+	}
+
+	return notStarted + len(t.tasks) - started
+}
+
+// doTask runs a single task and waits for it to complete, sub-tasks are run
+// with the given context; it returns whether any errors have occurred and
+// how many of the sub-tasks were not started
+func doTask(ctx context.Context, task Task, allErrs chan error) (bool, int) {
+	if subTree, ok := task.(*TaskTree); ok {
+		if subTree.Len() == 0 || subTree.PlanMode {
+			return false, 0
+		}
+		errs := make(chan error)
+		notStarted := make(chan int, 1)
+		go func() {
+			defer close(errs)
+			notStarted <- subTree.run(ctx, errs)
+		}()
+		failed := false
+		for err := range errs {
+			failed = true
+			allErrs <- err
+		}
+		return failed, <-notStarted
+	}
+
+	desc := task.Describe()
+	logger.Debug("started task: %s", desc)
+	errs := make(chan error)
+	if err := task.Do(errs); err != nil {
+		allErrs <- err
+		return true, 0
+	}
+	if err := <-errs; err != nil {
+		allErrs <- err
+		return true, 0
+	}
+	logger.Debug("completed task: %s", desc)
+	return false, 0
+}
+
+// contextWithInterrupt returns a context that gets cancelled when an interrupt
+// signal is received, after that the signal is no longer handled, so the next
+// one terminates the process as usual; stop must be called to release resources
+func contextWithInterrupt(parent context.Context) (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			logger.Warning("interrupted, waiting for tasks that are already running to complete, no new tasks will be started (interrupt again to exit immediately)")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
 type taskWithoutParams struct {
 	info string
 	call func(chan error) error
@@ -177,39 +487,3 @@ func (t *asyncTaskWithStackSpec) Do(errs chan error) error {
 	close(errs)
 	return err
 }
-
-func doSingleTask(allErrs chan error, task Task) {
-	desc := task.Describe()
-	logger.Debug("started task: %s", desc)
-	errs := make(chan error)
-	if err := task.Do(errs); err != nil {
-		allErrs <- err
-		return
-	}
-	if err := <-errs; err != nil {
-		allErrs <- err
-		return
-	}
-	logger.Debug("completed task: %s", desc)
-}
-
-func doParallelTasks(allErrs chan error, tasks []Task) {
-	wg := &sync.WaitGroup{}
-	wg.Add(len(tasks))
-	for t := range tasks {
-		go func(t int) {
-			defer wg.Done()
-			doSingleTask(allErrs, tasks[t])
-		}(t)
-	}
-	logger.Debug("waiting for %d parallel tasks to complete", len(tasks))
-	wg.Wait()
-	close(allErrs)
-}
-
-func doSequentialTasks(allErrs chan error, tasks []Task) {
-	for t := range tasks {
-		doSingleTask(allErrs, tasks[t])
-	}
-	close(allErrs)
-}
@@ -1,7 +1,9 @@
 package manager
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -175,11 +177,11 @@ var _ = Describe("StackCollection Tasks", func() {
 
 					status.startTime = time.Now()
 					errs := tasks.DoAllSync()
-					Expect(errs).To(HaveLen(2))
+					// t2.1 is not started, as t3.2 has failed
+					Expect(errs).To(HaveLen(1))
 					Expect(errs[0].Error()).To(Equal("t3.2 always fails"))
-					Expect(errs[1].Error()).To(Equal("t2.1 always fails"))
 
-					Expect(status.messages).To(HaveLen(8))
+					Expect(status.messages).To(HaveLen(6))
 
 					Expect(status.messages[0]).To(
 						Equal("0s: started t1.1"),
@@ -200,12 +202,6 @@ var _ = Describe("StackCollection Tasks", func() {
 					Expect(status.messages[5]).To(Equal(
 						"450ms: finished t3.2",
 					))
-					Expect(status.messages[6]).To(Equal(
-						"450ms: started t2.1",
-					))
-					Expect(status.messages[7]).To(Equal(
-						"600ms: finished t2.1",
-					))
 				}
 
 				{
@@ -381,12 +377,172 @@ var _ = Describe("StackCollection Tasks", func() {
 
 					tasks.PlanMode = false
 					errs := tasks.DoAllSync()
-					Expect(errs).To(HaveLen(2))
+					Expect(errs).To(HaveLen(1))
 					Expect(errs[0].Error()).To(Equal("t1.1 always fails"))
-					Expect(errs[1].Error()).To(Equal("t1.3 always fails"))
 				}
 
 			})
+
+			Context("With dependencies and limits", func() {
+				var (
+					mutex   sync.Mutex
+					events  []string
+					running int32
+					maxSeen int32
+				)
+
+				newTask := func(info string, delay time.Duration, err error) Task {
+					return &taskWithoutParams{
+						info: info,
+						call: func(errs chan error) error {
+							mutex.Lock()
+							events = append(events, "started "+info)
+							mutex.Unlock()
+							n := atomic.AddInt32(&running, 1)
+							for {
+								seen := atomic.LoadInt32(&maxSeen)
+								if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+									break
+								}
+							}
+							go func() {
+								time.Sleep(delay)
+								atomic.AddInt32(&running, -1)
+								mutex.Lock()
+								events = append(events, "finished "+info)
+								mutex.Unlock()
+								errs <- err
+								close(errs)
+							}()
+							return nil
+						},
+					}
+				}
+
+				BeforeEach(func() {
+					events = []string{}
+					running = 0
+					maxSeen = 0
+				})
+
+				It("should start tasks once their dependencies complete", func() {
+					tasks := &TaskTree{Parallel: true}
+					a := newTask("a", 50*time.Millisecond, nil)
+					b := newTask("b", 10*time.Millisecond, nil)
+					c := newTask("c", 10*time.Millisecond, nil)
+					tasks.Append(a, b)
+					tasks.AppendWithDependencies(c, a, b)
+
+					Expect(tasks.DoAllSync()).To(HaveLen(0))
+					Expect(events).To(HaveLen(6))
+					Expect(events[4:]).To(Equal([]string{"started c", "finished c"}))
+				})
+
+				It("should not start more tasks than allowed", func() {
+					tasks := &TaskTree{Parallel: true}
+					for i := 0; i < 6; i++ {
+						tasks.Append(newTask(fmt.Sprintf("t%d", i), 20*time.Millisecond, nil))
+					}
+					outer := &TaskTree{Parallel: false}
+					tasks.IsSubTask = true
+					outer.Append(tasks)
+					outer.LimitConcurrency(2)
+					Expect(tasks.MaxConcurrency).To(Equal(2))
+
+					Expect(outer.DoAllSync()).To(HaveLen(0))
+					Expect(events).To(HaveLen(12))
+					Expect(atomic.LoadInt32(&maxSeen)).To(Equal(int32(2)))
+				})
+
+				It("should stop starting new tasks after the first error", func() {
+					tasks := &TaskTree{Parallel: true, MaxConcurrency: 1}
+					tasks.Append(newTask("t1", 10*time.Millisecond, nil))
+					tasks.Append(newTask("t2", 10*time.Millisecond, fmt.Errorf("t2 always fails")))
+					tasks.Append(newTask("t3", 10*time.Millisecond, nil))
+
+					errs := tasks.DoAllSync()
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("t2 always fails"))
+					Expect(events).To(Equal([]string{"started t1", "finished t1", "started t2", "finished t2"}))
+				})
+
+				It("should skip tasks that depend on a failed task", func() {
+					tasks := &TaskTree{Parallel: true}
+					a := newTask("a", 10*time.Millisecond, fmt.Errorf("a always fails"))
+					b := newTask("b", 50*time.Millisecond, nil)
+					tasks.Append(a, b)
+					tasks.AppendWithDependencies(newTask("c", 10*time.Millisecond, nil), a)
+
+					errs := tasks.DoAllSync()
+					Expect(errs).To(HaveLen(1))
+					Expect(events).To(ConsistOf("started a", "finished a", "started b", "finished b"))
+				})
+
+				It("should stop starting new tasks when cancelled", func() {
+					tasks := &TaskTree{Parallel: false}
+					tasks.Append(newTask("t1", 50*time.Millisecond, nil))
+					subTask := &TaskTree{Parallel: true, IsSubTask: true}
+					subTask.Append(newTask("t2.1", 10*time.Millisecond, nil))
+					subTask.Append(newTask("t2.2", 10*time.Millisecond, nil))
+					tasks.Append(subTask)
+
+					ctx, cancel := context.WithCancel(context.Background())
+					time.AfterFunc(10*time.Millisecond, cancel)
+
+					errs := tasks.DoAllSyncWithContext(ctx)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("1 task(s) were not started, as the operation was cancelled"))
+					Expect(events).To(Equal([]string{"started t1", "finished t1"}))
+				})
+
+				It("should reject invalid dependencies", func() {
+					{
+						tasks := &TaskTree{Parallel: true}
+						tasks.AppendWithDependencies(newTask("a", 0, nil), newTask("b", 0, nil))
+						errs := tasks.DoAllSync()
+						Expect(errs).To(HaveLen(1))
+						Expect(errs[0].Error()).To(Equal(`task "a" depends on "b", which is not in the same set of tasks`))
+					}
+					{
+						tasks := &TaskTree{Parallel: true}
+						a := newTask("a", 0, nil)
+						b := newTask("b", 0, nil)
+						tasks.AppendWithDependencies(a, b)
+						tasks.AppendWithDependencies(b, a)
+						Expect(tasks.Validate()).To(MatchError(`dependencies of tasks in "2 parallel tasks: { a, b }" form a cycle`))
+						Expect(tasks.Do(make(chan error))).To(HaveOccurred())
+					}
+					Expect(events).To(BeEmpty())
+				})
+
+				It("should render a graph", func() {
+					tasks := &TaskTree{Parallel: false}
+					tasks.Append(newTask("create cluster", 0, nil))
+					subTask := &TaskTree{Parallel: true, IsSubTask: true}
+					a := newTask("cleanup a", 0, nil)
+					subTask.Append(a)
+					subTask.AppendWithDependencies(newTask("delete a", 0, nil), a)
+					subTask.Append(newTask("delete b", 0, nil))
+					single := &TaskTree{Parallel: false, IsSubTask: true}
+					single.Append(newTask("delete c", 0, nil))
+					subTask.Append(single)
+					tasks.Append(subTask)
+					tasks.LimitConcurrency(3)
+
+					Expect(tasks.RenderGraph()).To(Equal(strings.Join([]string{
+						"2 sequential tasks",
+						"  create cluster",
+						"  4 parallel sub-tasks (at most 3 at a time)",
+						"    cleanup a",
+						"    delete a (after: cleanup a)",
+						"    delete b",
+						"    delete c",
+					}, "\n")))
+
+					tasks.PlanMode = true
+					Expect(tasks.RenderGraph()).To(HavePrefix("(plan) 2 sequential tasks\n"))
+				})
+			})
 		})
 
 		Context("With real tasks", func() {
@@ -5,8 +5,16 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 )
 
-// GetStackTemplate gets the Cloudformation template for a stack
+// GetStackTemplate gets the Cloudformation template for a stack, templates are
+// cached for the lifetime of the stack manager, until the stack is updated
 func (c *StackCollection) GetStackTemplate(stackName string) (string, error) {
+	c.templateCacheMutex.Lock()
+	template, ok := c.templateCache[stackName]
+	c.templateCacheMutex.Unlock()
+	if ok {
+		return template, nil
+	}
+
 	input := &cloudformation.GetTemplateInput{
 		StackName: aws.String(stackName),
 	}
@@ -16,5 +24,19 @@ func (c *StackCollection) GetStackTemplate(stackName string) (string, error) {
 		return "", err
 	}
 
+	c.templateCacheMutex.Lock()
+	defer c.templateCacheMutex.Unlock()
+	if c.templateCache == nil {
+		c.templateCache = map[string]string{}
+	}
+	c.templateCache[stackName] = *output.TemplateBody
+
 	return *output.TemplateBody, nil
 }
+
+// forgetStackTemplate removes the template of a stack from the cache
+func (c *StackCollection) forgetStackTemplate(stackName string) {
+	c.templateCacheMutex.Lock()
+	defer c.templateCacheMutex.Unlock()
+	delete(c.templateCache, stackName)
+}
@@ -0,0 +1,118 @@
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// maxTemplateBodySize is the largest template that CloudFormation accepts inline
+const maxTemplateBodySize = 51200
+
+// templateURL uploads the template to S3 when a template bucket is set, or when the template
+// is too large to be passed inline, in which case a default bucket is used; it returns an
+// empty string when the template should be passed inline; uploaded templates are deleted
+// with deleteUploadedTemplate once CloudFormation no longer needs them
+func (c *StackCollection) templateURL(stackName string, templateBody []byte) (string, error) {
+	bucket := c.provider.CloudFormationTemplateBucket()
+	if bucket == "" {
+		if len(templateBody) <= maxTemplateBodySize {
+			return "", nil
+		}
+		logger.Info("template of stack %q is %d bytes, which is more than %d bytes that can be passed inline, it will be uploaded to S3",
+			stackName, len(templateBody), maxTemplateBodySize)
+		var err error
+		if bucket, err = c.ensureDefaultTemplateBucket(); err != nil {
+			return "", err
+		}
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(fmt.Sprintf("%s/%s-%d.json", c.spec.Metadata.Name, stackName, time.Now().Unix())),
+		Body:        bytes.NewReader(templateBody),
+		ContentType: aws.String("application/json"),
+	}
+	req, _ := c.provider.S3().PutObjectRequest(input)
+	if err := req.Send(); err != nil {
+		return "", errors.Wrapf(err, "uploading template of stack %q to S3 bucket %q", stackName, bucket)
+	}
+
+	c.templateBucketMutex.Lock()
+	if c.uploadedTemplates == nil {
+		c.uploadedTemplates = map[string]*s3.DeleteObjectInput{}
+	}
+	c.uploadedTemplates[stackName] = &s3.DeleteObjectInput{Bucket: input.Bucket, Key: input.Key}
+	c.templateBucketMutex.Unlock()
+
+	url := *req.HTTPRequest.URL
+	url.RawQuery = ""
+	logger.Debug("uploaded template of stack %q to %s", stackName, url.String())
+	return url.String(), nil
+}
+
+// ensureDefaultTemplateBucket creates a bucket for templates of the current account
+// and region, unless it already exists
+func (c *StackCollection) ensureDefaultTemplateBucket() (string, error) {
+	c.templateBucketMutex.Lock()
+	defer c.templateBucketMutex.Unlock()
+
+	if c.defaultTemplateBucket != "" {
+		return c.defaultTemplateBucket, nil
+	}
+
+	identity, err := c.provider.STS().GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "getting account ID for default template bucket")
+	}
+	bucket := fmt.Sprintf("eksctl-cfn-templates-%s-%s", *identity.Account, c.provider.Region())
+
+	_, err = c.provider.S3().HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	if err != nil {
+		if reqErr, ok := err.(awserr.RequestFailure); !ok || reqErr.StatusCode() != http.StatusNotFound {
+			return "", errors.Wrapf(err, "checking default template bucket %q", bucket)
+		}
+		logger.Info("creating S3 bucket %q for CloudFormation templates that are too large to be passed inline, templates are deleted from it once stacks are deployed", bucket)
+		input := &s3.CreateBucketInput{Bucket: aws.String(bucket)}
+		if region := c.provider.Region(); region != api.RegionUSEast1 {
+			// us-east-1 is the default location, which cannot be set explicitly
+			input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+				LocationConstraint: aws.String(region),
+			}
+		}
+		if _, err := c.provider.S3().CreateBucket(input); err != nil {
+			return "", errors.Wrapf(err, "creating default template bucket %q", bucket)
+		}
+	}
+
+	c.defaultTemplateBucket = bucket
+	return bucket, nil
+}
+
+// deleteUploadedTemplate deletes the template that was last uploaded for the stack, if any;
+// a failure to delete it is not fatal, as the stack operation doesn't depend on it
+func (c *StackCollection) deleteUploadedTemplate(stackName string) {
+	c.templateBucketMutex.Lock()
+	input, ok := c.uploadedTemplates[stackName]
+	delete(c.uploadedTemplates, stackName)
+	c.templateBucketMutex.Unlock()
+
+	if !ok {
+		return
+	}
+	if _, err := c.provider.S3().DeleteObject(input); err != nil {
+		logger.Warning("unable to delete template of stack %q from S3 bucket %q: %s", stackName, *input.Bucket, err.Error())
+		return
+	}
+	logger.Debug("deleted template of stack %q from S3 bucket %q", stackName, *input.Bucket)
+}
@@ -0,0 +1,161 @@
+package manager
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection template uploads", func() {
+	const stackName = "eksctl-test-cluster-nodegroup-ng-1"
+
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+
+		server  *httptest.Server
+		mutex   sync.Mutex
+		uploads map[string]string
+
+		createStackInput     *cfn.CreateStackInput
+		createChangeSetInput *cfn.CreateChangeSetInput
+	)
+
+	smallTemplate := []byte(`{"Resources":{}}`)
+	largeTemplate := []byte(`{"Description":"` + strings.Repeat("x", maxTemplateBodySize) + `","Resources":{}}`)
+
+	BeforeEach(func() {
+		uploads = map[string]string{}
+
+		// a minimal S3-compatible stand-in, which only stores uploaded objects
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).To(Equal(http.MethodPut))
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			mutex.Lock()
+			uploads[r.URL.Path] = string(body)
+			mutex.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		s3Client := s3.New(session.Must(session.NewSession()), aws.NewConfig().
+			WithEndpoint(server.URL).
+			WithRegion(api.DefaultRegion).
+			WithS3ForcePathStyle(true).
+			WithCredentials(credentials.NewStaticCredentials("id", "secret", "")))
+
+		p = mockprovider.NewMockProvider()
+
+		p.MockS3().On("PutObjectRequest", mock.Anything).Return(func(input *s3.PutObjectInput) *request.Request {
+			req, _ := s3Client.PutObjectRequest(input)
+			return req
+		}, nil)
+
+		p.MockS3().On("DeleteObject", mock.Anything).Return(&s3.DeleteObjectOutput{}, nil)
+
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Run(func(args mock.Arguments) {
+			createStackInput = args[0].(*cfn.CreateStackInput)
+		}).Return(&cfn.CreateStackOutput{StackId: aws.String("id")}, nil)
+
+		p.MockCloudFormation().On("CreateChangeSet", mock.Anything).Run(func(args mock.Arguments) {
+			createChangeSetInput = args[0].(*cfn.CreateChangeSetInput)
+		}).Return(&cfn.CreateChangeSetOutput{}, nil)
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		sc = NewStackCollection(p, cfg)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockprovider.ProviderConfig.CloudFormationTemplateBucket = ""
+	})
+
+	It("should pass small templates inline", func() {
+		Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(stackName)}, smallTemplate, nil, nil, false, false)).To(Succeed())
+
+		Expect(*createStackInput.TemplateBody).To(Equal(string(smallTemplate)))
+		Expect(createStackInput.TemplateURL).To(BeNil())
+		Expect(p.MockS3().AssertNumberOfCalls(GinkgoT(), "PutObjectRequest", 0)).To(BeTrue())
+	})
+
+	It("should upload all templates when a bucket is set", func() {
+		mockprovider.ProviderConfig.CloudFormationTemplateBucket = "templates"
+
+		Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(stackName)}, smallTemplate, nil, nil, false, false)).To(Succeed())
+
+		Expect(createStackInput.TemplateBody).To(BeNil())
+		Expect(*createStackInput.TemplateURL).To(HavePrefix(server.URL + "/templates/test-cluster/" + stackName + "-"))
+		Expect(uploads).To(HaveKeyWithValue(strings.TrimPrefix(*createStackInput.TemplateURL, server.URL), string(smallTemplate)))
+		// the template is deleted once the stack creation is requested
+		p.MockS3().AssertCalled(GinkgoT(), "DeleteObject", &s3.DeleteObjectInput{
+			Bucket: aws.String("templates"),
+			Key:    aws.String(strings.TrimPrefix(*createStackInput.TemplateURL, server.URL+"/templates/")),
+		})
+
+		Expect(sc.doCreateChangeSetRequest(&Stack{StackName: aws.String(stackName)}, "change-set", "", smallTemplate, nil, nil, false)).To(Succeed())
+
+		Expect(createChangeSetInput.TemplateBody).To(BeNil())
+		Expect(*createChangeSetInput.TemplateURL).To(HavePrefix(server.URL + "/templates/test-cluster/" + stackName + "-"))
+		// the template of a change set is only deleted once it is executed
+		Expect(p.MockS3().AssertNumberOfCalls(GinkgoT(), "DeleteObject", 1)).To(BeTrue())
+		sc.deleteUploadedTemplate(stackName)
+		Expect(p.MockS3().AssertNumberOfCalls(GinkgoT(), "DeleteObject", 2)).To(BeTrue())
+	})
+
+	It("should upload large templates to a default bucket, and create it when it doesn't exist", func() {
+		p.MockSTS().On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("123456789012"),
+		}, nil)
+		p.MockS3().On("HeadBucket", mock.Anything).Return(nil, awserr.NewRequestFailure(awserr.New("NotFound", "Not Found", nil), http.StatusNotFound, "request-id"))
+		p.MockS3().On("CreateBucket", mock.Anything).Return(&s3.CreateBucketOutput{}, nil)
+
+		Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String(stackName)}, largeTemplate, nil, nil, false, false)).To(Succeed())
+		Expect(sc.doCreateChangeSetRequest(&Stack{StackName: aws.String(stackName)}, "change-set", "", largeTemplate, nil, nil, false)).To(Succeed())
+
+		bucket := "eksctl-cfn-templates-123456789012-" + api.DefaultRegion
+		p.MockS3().AssertCalled(GinkgoT(), "CreateBucket", &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+			CreateBucketConfiguration: &s3.CreateBucketConfiguration{
+				LocationConstraint: aws.String(api.DefaultRegion),
+			},
+		})
+		// the bucket is only checked once
+		Expect(p.MockS3().AssertNumberOfCalls(GinkgoT(), "HeadBucket", 1)).To(BeTrue())
+
+		Expect(createStackInput.TemplateBody).To(BeNil())
+		Expect(*createStackInput.TemplateURL).To(HavePrefix(server.URL + "/" + bucket + "/test-cluster/"))
+		Expect(*createChangeSetInput.TemplateURL).To(HavePrefix(server.URL + "/" + bucket + "/test-cluster/"))
+		Expect(uploads).To(HaveKeyWithValue(strings.TrimPrefix(*createStackInput.TemplateURL, server.URL), string(largeTemplate)))
+	})
+
+	It("should not use a default bucket when it's not accessible", func() {
+		p.MockSTS().On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("123456789012"),
+		}, nil)
+		p.MockS3().On("HeadBucket", mock.Anything).Return(nil, awserr.NewRequestFailure(awserr.New("Forbidden", "Forbidden", nil), http.StatusForbidden, "request-id"))
+
+		err := sc.DoCreateStackRequest(&Stack{StackName: aws.String(stackName)}, largeTemplate, nil, nil, false, false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix(`checking default template bucket "eksctl-cfn-templates-123456789012-us-west-2"`))
+		Expect(p.MockS3().AssertNumberOfCalls(GinkgoT(), "CreateBucket", 0)).To(BeTrue())
+	})
+})
@@ -36,13 +36,21 @@ func (c *StackCollection) waitWithAcceptors(i *Stack, acceptors []request.Waiter
 		return req
 	}
 
+	stopStreaming, streaming := c.streamStackEvents(i)
+	defer stopStreaming()
+
 	troubleshoot := func(desiredStatus string) {
+		// make sure all events are logged before status is reported
+		stopStreaming()
 		s, err := c.DescribeStack(i)
 		if err != nil {
 			logger.Debug("describeErr=%v", err)
 		} else {
 			logger.Critical("unexpected status %q while %s", *s.StackStatus, msg)
-			c.troubleshootStackFailureCause(i, desiredStatus)
+			if !streaming {
+				// events were not streamed, so it's necessary to fetch them now
+				c.troubleshootStackFailureCause(i, desiredStatus)
+			}
 		}
 	}
 
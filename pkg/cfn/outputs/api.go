@@ -30,6 +30,14 @@ const (
 	NodeGroupInstanceRoleARN    = "InstanceRoleARN"
 	NodeGroupInstanceProfileARN = "InstanceProfileARN"
 
+	// outputs that summarise nodegroup configuration, so that it can be
+	// listed without fetching templates; sizes are updated on scaling
+	NodeGroupInstanceType    = "InstanceType"
+	NodeGroupImageID         = "ImageID"
+	NodeGroupMinSize         = "MinSize"
+	NodeGroupMaxSize         = "MaxSize"
+	NodeGroupDesiredCapacity = "DesiredCapacity"
+
 	// outputs to indicate configuration attributes that may have critical effect
 	// on critical effect on forward-compatibility with respect to overal functionality
 	// and integrity, e.g. networking
@@ -85,12 +85,34 @@ func AddCommonFlagsForAWS(group *NamedFlagSetGroup, p *api.ProviderConfig, cfnRo
 			logger.Debug("ignoring error %q", err.Error())
 		}
 		fs.DurationVar(&p.WaitTimeout, "timeout", api.DefaultWaitTimeout, "max wait time in any polling operations")
+		p.StackEvents = api.StackEventsCompact
+		fs.Var((*stackEventsValue)(&p.StackEvents), "cfn-events", fmt.Sprintf("how to show CloudFormation stack events while waiting (valid options: %s)", strings.Join(stackEventsFormats, ", ")))
 		if cfnRole {
 			fs.StringVar(&p.CloudFormationRoleARN, "cfn-role-arn", "", "IAM role used by CloudFormation to call AWS API on your behalf")
 		}
+		fs.StringVar(&p.CloudFormationTemplateBucket, "cfn-template-bucket", "", "S3 bucket to upload CloudFormation templates to (if unspecified, templates that are too large to be passed inline are uploaded to a default bucket)")
 	})
 }
 
+var stackEventsFormats = []string{api.StackEventsCompact, api.StackEventsJSON, api.StackEventsNone}
+
+// stackEventsValue is a flag value that only accepts known formats of stack events
+type stackEventsValue string
+
+func (v *stackEventsValue) String() string { return string(*v) }
+
+func (v *stackEventsValue) Type() string { return "string" }
+
+func (v *stackEventsValue) Set(format string) error {
+	for _, f := range stackEventsFormats {
+		if format == f {
+			*v = stackEventsValue(format)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q, must be one of %s", format, strings.Join(stackEventsFormats, ", "))
+}
+
 // AddRegionFlag adds common --region flag
 func AddRegionFlag(fs *pflag.FlagSet, p *api.ProviderConfig) {
 	fs.StringVarP(&p.Region, "region", "r", "", "AWS region")
@@ -115,6 +137,11 @@ func AddUpdateAuthConfigMap(updateAuthConfigMap *bool, fs *pflag.FlagSet, descri
 	fs.BoolVar(updateAuthConfigMap, "update-auth-configmap", true, description)
 }
 
+// AddMaxConcurrencyFlag adds common --max-concurrency flag
+func AddMaxConcurrencyFlag(maxConcurrency *int, fs *pflag.FlagSet) {
+	fs.IntVar(maxConcurrency, "max-concurrency", 0, "maximum number of nodegroup stacks to create at the same time, can be used to avoid CloudFormation API throttling (unlimited if unspecified)")
+}
+
 // AddCommonFlagsForKubeconfig adds common flags for controlling how output kubeconfig is written
 func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath *string, setContext, autoPath *bool, exampleName string) {
 	fs.StringVar(outputPath, "kubeconfig", kubeconfig.DefaultPath, "path to write kubeconfig (incompatible with --auto-kubeconfig)")
@@ -122,6 +149,13 @@ func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath *string, setConte
 	fs.BoolVar(autoPath, "auto-kubeconfig", false, fmt.Sprintf("save kubeconfig file by cluster name, e.g. %q", kubeconfig.AutoPath(exampleName)))
 }
 
+// AddKubeconfigAuthenticatorFlags adds flags for configuring the authenticator used in kubeconfig
+func AddKubeconfigAuthenticatorFlags(fs *pflag.FlagSet, authenticator *api.KubeconfigAuthenticator) {
+	fs.StringVar(&authenticator.Command, "authenticator", "", fmt.Sprintf("authenticator command to use in kubeconfig (valid options: %s, %s, %s; detected if unspecified)", kubeconfig.AWSIAMAuthenticator, kubeconfig.HeptioAuthenticatorAWS, kubeconfig.EksctlAuthenticator))
+	fs.StringVar(&authenticator.RoleARN, "authenticator-role-arn", "", "ARN of the IAM role that the authenticator assumes for generating tokens")
+	fs.StringToStringVar(&authenticator.Env, "authenticator-env", nil, `extra environment variables for the authenticator, e.g. "AWS_STS_REGIONAL_ENDPOINTS=regional"`)
+}
+
 // AddCommonFlagsForGetCmd adds common flafs for get commands
 func AddCommonFlagsForGetCmd(fs *pflag.FlagSet, chunkSize *int, outputMode *string) {
 	fs.IntVar(chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
@@ -147,3 +181,8 @@ func ErrMustBeSet(pathOrFlag string) error {
 func ErrCannotUseWithConfigFile(what string) error {
 	return fmt.Errorf("cannot use %s when --config-file/-f is set", what)
 }
+
+// AddIAMIdentityMappingARNFlag adds common --arn flag for IAM identity mapping commands
+func AddIAMIdentityMappingARNFlag(iamARN *string, fs *pflag.FlagSet) {
+	fs.StringVar(iamARN, "arn", "", "ARN of the IAM role or user, or ID of the account to map")
+}
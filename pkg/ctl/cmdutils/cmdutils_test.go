@@ -0,0 +1,42 @@
+package cmdutils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("common flags for AWS", func() {
+	var (
+		p   *api.ProviderConfig
+		cmd *cobra.Command
+	)
+
+	BeforeEach(func() {
+		p = &api.ProviderConfig{}
+		cmd = &cobra.Command{Use: "test"}
+		group := NewGrouping().New(cmd)
+		AddCommonFlagsForAWS(group, p, false)
+		group.AddTo(cmd)
+	})
+
+	It("should default to compact stack events", func() {
+		Expect(cmd.ParseFlags([]string{})).To(Succeed())
+		Expect(p.StackEvents).To(Equal(api.StackEventsCompact))
+	})
+
+	It("should accept known stack events formats", func() {
+		Expect(cmd.ParseFlags([]string{"--cfn-events=json"})).To(Succeed())
+		Expect(p.StackEvents).To(Equal(api.StackEventsJSON))
+	})
+
+	It("should reject unknown stack events formats", func() {
+		err := cmd.ParseFlags([]string{"--cfn-events=jsno"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown format "jsno"`))
+		Expect(p.StackEvents).To(Equal(api.StackEventsCompact))
+	})
+})
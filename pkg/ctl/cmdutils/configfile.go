@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/eks"
 )
 
@@ -163,6 +164,46 @@ func NewCreateClusterLoader(provider *api.ProviderConfig, spec *api.ClusterConfi
 			return fmt.Errorf("vpc.subnets and availabilityZones cannot be set at the same time")
 		}
 
+		if l.spec.VPC.Selector != nil {
+			if l.spec.HasAnySubnets() {
+				return fmt.Errorf("vpc.selector and vpc.subnets cannot be set at the same time")
+			}
+			if l.spec.VPC.ID != "" {
+				return fmt.Errorf("vpc.selector and vpc.id cannot be set at the same time")
+			}
+			if len(l.spec.AvailabilityZones) != 0 {
+				return fmt.Errorf("vpc.selector and availabilityZones cannot be set at the same time")
+			}
+			if len(l.spec.VPC.Selector.Tags) == 0 {
+				return fmt.Errorf("vpc.selector.tags must be set")
+			}
+		}
+
+		if err := api.ValidateClusterVPC(l.spec.VPC); err != nil {
+			return err
+		}
+
+		if err := api.ValidateClusterIAM(&l.spec.IAM); err != nil {
+			return err
+		}
+
+		if err := api.ValidateCloudFormationExtras(l.spec.CloudFormation, "cloudFormation"); err != nil {
+			return err
+		}
+
+		if err := api.ValidateIAMIdentityMappings(l.spec.IAMIdentityMappings); err != nil {
+			return err
+		}
+		if _, err := authconfigmap.IdentitiesFromConfig(l.spec.IAMIdentityMappings); err != nil {
+			return err
+		}
+
+		if l.spec.Kubeconfig != nil {
+			if err := api.ValidateKubeconfigAuthenticator(l.spec.Kubeconfig.Authenticator, "kubeconfig.authenticator"); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}
 
@@ -228,6 +269,9 @@ func NewCreateNodeGroupLoader(provider *api.ProviderConfig, spec *api.ClusterCon
 	)
 
 	l.validateWithConfigFile = func() error {
+		if err := api.ValidateClusterIAM(&spec.IAM); err != nil {
+			return err
+		}
 		if err := ngFilter.AppendGlobs(include, exclude, spec.NodeGroups); err != nil {
 			return err
 		}
@@ -0,0 +1,103 @@
+package cmdutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/lock"
+)
+
+// Lock backends that can be selected with --lock-backend
+const (
+	LockBackendLockStack = "lock-stack"
+	LockBackendStack     = "stack"
+	LockBackendConfigMap = "configmap"
+	LockBackendFile      = "file"
+	LockBackendNone      = "none"
+)
+
+// LockOptions holds the values of the lock flags
+type LockOptions struct {
+	Backend string
+	TTL     time.Duration
+}
+
+// AddLockFlags adds common flags for locking the cluster during mutating operations
+func AddLockFlags(group *NamedFlagSetGroup, o *LockOptions) {
+	group.InFlagSet("Locking", func(fs *pflag.FlagSet) {
+		AddLockBackendFlag(fs, o)
+		fs.DurationVar(&o.TTL, "lock-ttl", lock.DefaultTTL, "how long the lock is held, if it's not released earlier; an expired lock is taken over by the next operation")
+	})
+}
+
+// AddLockBackendFlag adds common --lock-backend flag
+func AddLockBackendFlag(fs *pflag.FlagSet, o *LockOptions) {
+	fs.StringVar(&o.Backend, "lock-backend", LockBackendLockStack, fmt.Sprintf("where the cluster lock is stored, %q uses a dedicated stack, %q a tag of the cluster stack (valid options: %s)",
+		LockBackendLockStack, LockBackendStack, strings.Join([]string{LockBackendLockStack, LockBackendStack, LockBackendConfigMap, LockBackendFile, LockBackendNone}, ", ")))
+}
+
+// NewLockBackend returns the lock backend selected by the flags, it returns nil when locking is disabled
+func NewLockBackend(ctl *eks.ClusterProvider, cfg *api.ClusterConfig, o *LockOptions) (lock.Backend, error) {
+	switch o.Backend {
+	case LockBackendLockStack:
+		return ctl.NewStackManager(cfg).NewLockStackBackend(), nil
+	case LockBackendStack:
+		return ctl.NewStackManager(cfg).NewLockBackend(), nil
+	case LockBackendConfigMap:
+		if err := ctl.GetCredentials(cfg); err != nil {
+			return nil, errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+		}
+		clientSet, err := ctl.NewStdClientSet(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return lock.NewConfigMapBackend(clientSet), nil
+	case LockBackendFile:
+		path := filepath.Join(os.TempDir(), "eksctl-locks", fmt.Sprintf("%s.%s.json", cfg.Metadata.Name, cfg.Metadata.Region))
+		return lock.NewFileBackend(path), nil
+	case LockBackendNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown lock backend %q", o.Backend)
+	}
+}
+
+// LockCluster takes the advisory lock of the cluster for the given operation,
+// the returned function releases the lock and must be called once the operation
+// is done; when plan is true, nothing is mutated and the cluster is not locked
+func LockCluster(ctl *eks.ClusterProvider, cfg *api.ClusterConfig, o *LockOptions, operation string, plan bool) (func(), error) {
+	noop := func() {}
+	if plan {
+		return noop, nil
+	}
+	backend, err := NewLockBackend(ctl, cfg, o)
+	if err != nil || backend == nil {
+		return noop, err
+	}
+
+	locker := &lock.Locker{Backend: backend, TTL: o.TTL}
+	l, err := locker.Acquire(operation)
+	if err != nil {
+		if _, ok := err.(*lock.LockedError); ok {
+			return noop, fmt.Errorf("%s; if you are sure that no other operation is running, run 'eksctl utils force-unlock --name=%s --region=%s --lock-backend=%s'",
+				err.Error(), cfg.Metadata.Name, cfg.Metadata.Region, o.Backend)
+		}
+		return noop, errors.Wrapf(err, "locking cluster %q", cfg.Metadata.Name)
+	}
+	logger.Debug("locked cluster %q for %q until %s", cfg.Metadata.Name, operation, l.Expires.Local().Format(time.RFC3339))
+
+	return func() {
+		if err := locker.Release(l); err != nil {
+			logger.Warning("failed to release the lock of cluster %q: %s", cfg.Metadata.Name, err.Error())
+		}
+	}, nil
+}
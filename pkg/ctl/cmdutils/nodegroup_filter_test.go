@@ -338,7 +338,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  }
 		  },
@@ -373,7 +376,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  }
 		  },
@@ -406,7 +412,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  },
 			  "clusterDNS": "1.2.3.4"
@@ -440,7 +449,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  }
 		  },
@@ -476,7 +488,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  },
 			  "clusterDNS": "4.2.8.14"
@@ -513,7 +528,10 @@ const expected = `
 			  	"ebs": false,
 			  	"fsx": false,
 			  	"efs": false,
-			  	"albIngress": false
+			  	"albIngress": false,
+			  	"cloudWatch": false,
+			  	"xRay": false,
+			  	"certManager": false
 			    }
 			  }
 		  }
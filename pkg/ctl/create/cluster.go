@@ -12,8 +12,10 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
 	"github.com/weaveworks/eksctl/pkg/kops"
 	"github.com/weaveworks/eksctl/pkg/printers"
 	"github.com/weaveworks/eksctl/pkg/utils"
@@ -32,6 +34,8 @@ var (
 	subnets               map[api.SubnetTopology]*[]string
 	addonsStorageClass    bool
 	withoutNodeGroup      bool
+	resume                bool
+	iamPreflight          bool
 )
 
 func createClusterCmd(g *cmdutils.Grouping) *cobra.Command {
@@ -62,6 +66,9 @@ func createClusterCmd(g *cmdutils.Grouping) *cobra.Command {
 		fs.StringSliceVar(&availabilityZones, "zones", nil, "(auto-select if unspecified)")
 		cmdutils.AddVersionFlag(fs, cfg.Metadata, "")
 		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		fs.BoolVar(&resume, "resume", false, "resume creation of a cluster that had previously failed, keeping stacks that were created successfully and re-creating failed ones")
+		cmdutils.AddMaxConcurrencyFlag(&maxConcurrency, fs)
+		fs.BoolVar(&iamPreflight, "iam-preflight", true, "check that the calling identity has all IAM permissions needed to create the cluster before creating any stacks, and fail when any of these are explicitly denied")
 	})
 
 	group.InFlagSet("Initial nodegroup", func(fs *pflag.FlagSet) {
@@ -107,6 +114,7 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
 		return err
 	}
+	api.SetClusterVPCDefaults(cfg.VPC)
 
 	meta := cfg.Metadata
 	printer := printers.NewJSONPrinter()
@@ -136,6 +144,19 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		return err
 	}
 
+	stackManager := ctl.NewStackManager(cfg)
+
+	var existingStacks *manager.ExistingStacks
+	if resume {
+		var err error
+		if existingStacks, err = stackManager.DescribeExistingStacks(); err != nil {
+			return err
+		}
+	}
+	// when resuming, the VPC of existing cluster stack must be used,
+	// as the stack was already created successfully
+	useExistingClusterStack := existingStacks != nil && existingStacks.Cluster != nil && stackManager.StackIsHealthy(existingStacks.Cluster)
+
 	if autoKubeconfigPath {
 		if kubeconfigPath != kubeconfig.DefaultPath {
 			return fmt.Errorf("--kubeconfig and --auto-kubeconfig %s", cmdutils.IncompatibleFlags)
@@ -173,6 +194,22 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 			return nil
 		}
 
+		if cfg.VPC.Selector != nil {
+			// discover VPC and subnets using tags given in vpc.selector,
+			// which can only be set in a config file
+			if err := vpc.ImportFromSelector(ctl.Provider, cfg); err != nil {
+				return err
+			}
+
+			if err := ngFilter.ForEach(cfg.NodeGroups, canUseForPrivateNodeGroups); err != nil {
+				return err
+			}
+
+			logger.Success("using %s found by selector", subnetInfo())
+			logger.Warning(customNetworkingNotice)
+			return nil
+		}
+
 		if !subnetsGiven && kopsClusterNameForVPC == "" {
 			// default: create dedicated VPC
 			if err := ctl.SetAvailabilityZones(cfg, availabilityZones); err != nil {
@@ -242,7 +279,12 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		return nil
 	}
 
-	if err := createOrImportVPC(); err != nil {
+	if useExistingClusterStack {
+		logger.Info("using VPC of existing cluster stack %q", *existingStacks.Cluster.StackName)
+		if err := ctl.UseExistingClusterStack(existingStacks.Cluster, cfg); err != nil {
+			return err
+		}
+	} else if err := createOrImportVPC(); err != nil {
 		return err
 	}
 
@@ -270,6 +312,12 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		return err
 	}
 
+	if iamPreflight {
+		if err := checkIAMPermissions(ctl, p, cfg, ngFilter); err != nil {
+			return err
+		}
+	}
+
 	logger.Info("creating %s", meta.LogString())
 
 	// TODO dry-run mode should provide a way to render config with all defaults set
@@ -282,7 +330,6 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 
 	{ // core action
 		ngSubset, _ := ngFilter.MatchAll(cfg.NodeGroups)
-		stackManager := ctl.NewStackManager(cfg)
 		if ngCount := ngSubset.Len(); ngCount == 1 && clusterConfigFile == "" {
 			logger.Info("will create 2 separate CloudFormation stacks for cluster itself and the initial nodegroup")
 		} else {
@@ -291,10 +338,28 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		}
 		logger.Info("if you encounter any issues, check CloudFormation console or try 'eksctl utils describe-stacks --region=%s --name=%s'", meta.Region, meta.Name)
 		tasks := stackManager.NewTasksToCreateClusterWithNodeGroups(ngSubset)
+		if resume {
+			var err error
+			if tasks, err = stackManager.NewTasksToResumeClusterWithNodeGroups(existingStacks, ngSubset); err != nil {
+				return err
+			}
+			err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+				if s, ok := existingStacks.NodeGroups[ng.Name]; ok && stackManager.StackIsHealthy(s) {
+					return stackManager.UseExistingNodeGroupStack(ng, s)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		tasks.LimitConcurrency(maxConcurrency)
 		logger.Info(tasks.Describe())
+		logger.Debug("task graph:\n%s", tasks.RenderGraph())
 		if errs := tasks.DoAllSync(); len(errs) > 0 {
 			logger.Info("%d error(s) occurred and cluster hasn't been created properly, you may wish to check CloudFormation console", len(errs))
 			logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+			logger.Info("to retry creation of failed stacks, re-run this command with '--resume'")
 			for _, err := range errs {
 				logger.Critical("%s\n", err.Error())
 			}
@@ -336,8 +401,12 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		}
 
 		err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
-			// authorise nodes to join
-			if err = authconfigmap.AddNodeGroup(clientSet, ng); err != nil {
+			// authorise nodes to join, when resuming the role may have been added already
+			addNodeGroup := authconfigmap.AddNodeGroup
+			if resume {
+				addNodeGroup = authconfigmap.EnsureNodeGroup
+			}
+			if err = addNodeGroup(clientSet, ng); err != nil {
 				return err
 			}
 
@@ -358,6 +427,12 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 			return err
 		}
 
+		if cfg.IAMIdentityMappings != nil {
+			if _, err := authconfigmap.SyncIAMIdentityMappings(clientSet, cfg.IAMIdentityMappings, false); err != nil {
+				return errors.Wrap(err, "syncing iamIdentityMappings")
+			}
+		}
+
 		// add default storage class only for version 1.10 clusters
 		if meta.Version == "1.10" {
 			// --storage-class flag is only for backwards compatibility,
@@ -391,3 +466,42 @@ func doCreateCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 
 	return nil
 }
+
+// checkIAMPermissions simulates policies of the calling identity for actions that are needed
+// to create the cluster, and fails with a list of the actions that are explicitly denied;
+// actions that are only implicitly denied are listed in a warning, as these may be allowed
+// by policies with conditions that cannot be simulated; if the simulation itself is not
+// allowed, a warning is logged and the check is skipped
+func checkIAMPermissions(ctl *eks.ClusterProvider, p *api.ProviderConfig, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) error {
+	nodeGroups := []*api.NodeGroup{}
+	for _, ng := range cfg.NodeGroups {
+		if ngFilter.Match(ng.Name) {
+			nodeGroups = append(nodeGroups, ng)
+		}
+	}
+
+	missing, err := ctl.CheckIAMPermissions(iam.RequiredActions(p, cfg, nodeGroups))
+	if err != nil {
+		logger.Warning("unable to check IAM permissions, use --iam-preflight=false to skip this check: %s", err.Error())
+		return nil
+	}
+	if len(missing) == 0 {
+		logger.Info("the calling identity has all IAM permissions needed to create the cluster")
+		return nil
+	}
+
+	denied := 0
+	for _, m := range missing {
+		if m.IsExplicitlyDenied() {
+			logger.Critical("denied IAM permission: %s", m.String())
+			denied++
+		} else {
+			logger.Warning("possibly missing IAM permission: %s", m.String())
+		}
+	}
+	if denied > 0 {
+		return fmt.Errorf("the calling identity is denied %d IAM permission(s) needed to create the cluster, run 'eksctl utils generate-iam-policy' to get a policy with all of them, or use --iam-preflight=false to skip this check", denied)
+	}
+	logger.Warning("%d IAM permission(s) needed to create the cluster are not allowed unconditionally, the cluster creation will fail unless these are allowed by policies with conditions, e.g. on MFA or tags", len(missing))
+	return nil
+}
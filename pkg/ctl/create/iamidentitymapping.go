@@ -0,0 +1,98 @@
+package create
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func createIAMIdentityMappingCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var (
+		iamARN   string
+		username string
+		groups   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "iamidentitymapping",
+		Short: "Create an IAM identity mapping",
+		Long: "Map an IAM role or user to a Kubernetes username and groups, or allow all users of an IAM account to access the cluster, " +
+			"by adding it to the aws-auth ConfigMap",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := doCreateIAMIdentityMapping(p, cfg, iamARN, username, groups); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddIAMIdentityMappingARNFlag(&iamARN, fs)
+		fs.StringVar(&username, "username", "", "Kubernetes username to map the IAM role or user to")
+		fs.StringSliceVar(&groups, "group", []string{}, "Kubernetes group(s) to map the IAM role or user to (can be given multiple times)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doCreateIAMIdentityMapping(p *api.ProviderConfig, cfg *api.ClusterConfig, iamARN, username string, groups []string) error {
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+	if iamARN == "" {
+		return cmdutils.ErrMustBeSet("--arn")
+	}
+
+	id, err := authconfigmap.NewIdentity(iamARN, username, groups)
+	if err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	if err := acm.AddIdentity(id); err != nil {
+		return err
+	}
+	if err := acm.Save(); err != nil {
+		return errors.Wrap(err, "saving auth ConfigMap")
+	}
+
+	logger.Success("mapped %s %q in cluster %q", id.Kind, id.ARN, cfg.Metadata.Name)
+	return nil
+}
@@ -22,6 +22,10 @@ var (
 
 	includeNodeGroups []string
 	excludeNodeGroups []string
+
+	maxConcurrency int
+
+	lockOpts cmdutils.LockOptions
 )
 
 func createNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
@@ -54,6 +58,7 @@ func createNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
 		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
 		cmdutils.AddNodeGroupFilterFlags(&includeNodeGroups, &excludeNodeGroups, fs)
 		cmdutils.AddUpdateAuthConfigMap(&updateAuthConfigMap, fs, "Remove nodegroup IAM role from aws-auth configmap")
+		cmdutils.AddMaxConcurrencyFlag(&maxConcurrency, fs)
 	})
 
 	group.InFlagSet("New nodegroup", func(fs *pflag.FlagSet) {
@@ -66,6 +71,7 @@ func createNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, true)
+	cmdutils.AddLockFlags(group, &lockOpts)
 
 	group.AddTo(cmd)
 
@@ -108,13 +114,19 @@ func doCreateNodeGroups(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		return errors.Wrapf(err, "getting VPC configuration for cluster %q", cfg.Metadata.Name)
 	}
 
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "create nodegroup", false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	stackManager := ctl.NewStackManager(cfg)
 
 	if err := ngFilter.SetExcludeExistingFilter(stackManager); err != nil {
 		return err
 	}
 
-	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+	err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
 		// resolve AMI
 		if err := ctl.EnsureAMI(meta.Version, ng); err != nil {
 			return err
@@ -156,7 +168,9 @@ func doCreateNodeGroups(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		}
 
 		tasks := stackManager.NewTasksToCreateNodeGroups(ngSubset)
+		tasks.LimitConcurrency(maxConcurrency)
 		logger.Info(tasks.Describe())
+		logger.Debug("task graph:\n%s", tasks.RenderGraph())
 		errs := tasks.DoAllSync()
 		if len(errs) > 0 {
 			logger.Info("%d error(s) occurred and nodegroups haven't been created properly, you may wish to check CloudFormation console", len(errs))
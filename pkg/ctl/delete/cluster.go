@@ -4,6 +4,7 @@ import (
 	"fmt"
 	ssh "github.com/weaveworks/eksctl/pkg/ssh"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
@@ -16,9 +17,12 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/elb"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
+var cleanupLoadBalancers bool
+
 func deleteClusterCmd(g *cmdutils.Grouping) *cobra.Command {
 	p := &api.ProviderConfig{}
 	cfg := api.NewClusterConfig()
@@ -41,9 +45,11 @@ func deleteClusterCmd(g *cmdutils.Grouping) *cobra.Command {
 		cmdutils.AddRegionFlag(fs, p)
 		cmdutils.AddWaitFlag(&wait, fs, "deletion of all resources")
 		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		fs.BoolVar(&cleanupLoadBalancers, "cleanup-load-balancers", false, "delete Services of type LoadBalancer, and wait for the ELBs/NLBs and their security groups to be removed before deleting the cluster")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, true)
+	cmdutils.AddLockFlags(group, &lockOpts)
 
 	group.AddTo(cmd)
 	return cmd
@@ -73,6 +79,46 @@ func deleteDeprecatedStacks(stackManager *manager.StackCollection) (bool, error)
 	return false, nil
 }
 
+func doCleanupLoadBalancers(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) {
+	if err := ctl.GetCredentials(cfg); err != nil {
+		logger.Warning("skipping cleanup of load balancers, unable to get credentials for cluster %q: %s", cfg.Metadata.Name, err.Error())
+		return
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		logger.Warning("skipping cleanup of load balancers, unable to create client for cluster %q: %s", cfg.Metadata.Name, err.Error())
+		return
+	}
+
+	logger.Info("cleaning up load balancers created by Kubernetes in cluster %q", cfg.Metadata.Name)
+	errs := elb.Cleanup(ctl.Provider, clientSet, cfg.Metadata.Name)
+	if len(errs) == 0 {
+		logger.Success("all load balancers created by Kubernetes were deleted")
+		return
+	}
+	logger.Warning("%d load balancer resource(s) could not be cleaned up, deletion of VPC may fail", len(errs))
+	for _, err := range errs {
+		logger.Warning(err.Error())
+	}
+}
+
+func checkProtection(stackManager *manager.StackCollection, meta *api.ClusterMeta) error {
+	protected, err := stackManager.DescribeProtectedStacks()
+	if err != nil {
+		return err
+	}
+	if len(protected) == 0 {
+		return nil
+	}
+	names := []string{}
+	for _, s := range protected {
+		names = append(names, *s.StackName)
+	}
+	logger.Info("termination protection is enabled on stack(s): %s", strings.Join(names, ", "))
+	logger.Info("to remove protection, run 'eksctl utils update-protection --region=%s --name=%s --enable=false --approve'", meta.Region, meta.Name)
+	return fmt.Errorf("cluster %q is protected, refusing to delete it", meta.Name)
+}
+
 func doDeleteCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg string, cmd *cobra.Command) error {
 	printer := printers.NewJSONPrinter()
 
@@ -99,6 +145,20 @@ func doDeleteCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 
 	stackManager := ctl.NewStackManager(cfg)
 
+	if err := checkProtection(stackManager, meta); err != nil {
+		return err
+	}
+
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "delete cluster", false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if cleanupLoadBalancers {
+		doCleanupLoadBalancers(ctl, cfg)
+	}
+
 	ssh.DeleteKeys(meta.Name, ctl.Provider)
 
 	kubeconfig.MaybeDeleteConfig(meta)
@@ -133,6 +193,7 @@ func doDeleteCluster(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg stri
 		}
 
 		logger.Info(tasks.Describe())
+		logger.Debug("task graph:\n%s", tasks.RenderGraph())
 		if errs := tasks.DoAllSync(); len(errs) > 0 {
 			return handleErrors(errs, "cluster with nodegroup(s)")
 		}
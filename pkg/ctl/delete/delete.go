@@ -11,6 +11,8 @@ var (
 	plan = true
 
 	clusterConfigFile = ""
+
+	lockOpts cmdutils.LockOptions
 )
 
 // Command will create the `delete` commands
@@ -27,6 +29,7 @@ func Command(g *cmdutils.Grouping) *cobra.Command {
 
 	cmd.AddCommand(deleteClusterCmd(g))
 	cmd.AddCommand(deleteNodeGroupCmd(g))
+	cmd.AddCommand(deleteIAMIdentityMappingCmd(g))
 
 	return cmd
 }
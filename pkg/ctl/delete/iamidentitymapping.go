@@ -0,0 +1,105 @@
+package delete
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func deleteIAMIdentityMappingCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var (
+		iamARN string
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "iamidentitymapping",
+		Short: "Delete an IAM identity mapping",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := doDeleteIAMIdentityMapping(p, cfg, iamARN, all); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddIAMIdentityMappingARNFlag(&iamARN, fs)
+		fs.BoolVar(&all, "all", false, "delete all mappings of the given ARN, if it's mapped more than once")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doDeleteIAMIdentityMapping(p *api.ProviderConfig, cfg *api.ClusterConfig, iamARN string, all bool) error {
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+	if iamARN == "" {
+		return cmdutils.ErrMustBeSet("--arn")
+	}
+
+	id, err := authconfigmap.NewIdentity(iamARN, "", nil)
+	if err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	if err := acm.RemoveIdentity(id, all); err != nil {
+		return err
+	}
+	if err := acm.Save(); err != nil {
+		return errors.Wrap(err, "saving auth ConfigMap")
+	}
+
+	identities, err := acm.Identities()
+	if err != nil {
+		return err
+	}
+	for _, remaining := range identities {
+		if remaining.ARN == id.ARN {
+			logger.Warning("%s %q is still mapped in cluster %q, use --all to delete all of its mappings", id.Kind, id.ARN, cfg.Metadata.Name)
+			return nil
+		}
+	}
+
+	logger.Success("deleted mapping of %s %q in cluster %q", id.Kind, id.ARN, cfg.Metadata.Name)
+	return nil
+}
@@ -58,6 +58,7 @@ func deleteNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, true)
+	cmdutils.AddLockFlags(group, &lockOpts)
 
 	group.AddTo(cmd)
 
@@ -86,6 +87,12 @@ func doDeleteNodeGroup(p *api.ProviderConfig, cfg *api.ClusterConfig, ng *api.No
 		return err
 	}
 
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "delete nodegroup", plan)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	stackManager := ctl.NewStackManager(cfg)
 
 	if clusterConfigFile != "" {
@@ -146,7 +153,12 @@ func doDeleteNodeGroup(p *api.ProviderConfig, cfg *api.ClusterConfig, ng *api.No
 			return err
 		}
 		tasks.PlanMode = plan
-		logger.Info(tasks.Describe())
+		if plan {
+			logger.Info("%s", tasks.RenderGraph())
+		} else {
+			logger.Info(tasks.Describe())
+			logger.Debug("task graph:\n%s", tasks.RenderGraph())
+		}
 		if errs := tasks.DoAllSync(); len(errs) > 0 {
 			return handleErrors(errs, "nodegroup(s)")
 		}
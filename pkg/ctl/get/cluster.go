@@ -34,7 +34,7 @@ func getClusterCmd(g *cmdutils.Grouping) *cobra.Command {
 
 	group.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
-		fs.BoolVarP(&listAllRegions, "all-regions", "A", false, "List clusters across all supported regions")
+		fs.BoolVarP(&listAllRegions, "all-regions", "A", false, "List clusters across all supported regions of the partition")
 		cmdutils.AddRegionFlag(fs, p)
 		cmdutils.AddCommonFlagsForGetCmd(fs, &chunkSize, &output)
 	})
@@ -25,6 +25,8 @@ func Command(g *cmdutils.Grouping) *cobra.Command {
 
 	cmd.AddCommand(getClusterCmd(g))
 	cmd.AddCommand(getNodegroupCmd(g))
+	cmd.AddCommand(getIAMIdentityMappingCmd(g))
+	cmd.AddCommand(getTokenCmd(g))
 
 	return cmd
 }
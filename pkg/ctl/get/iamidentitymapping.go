@@ -0,0 +1,120 @@
+package get
+
+import (
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func getIAMIdentityMappingCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var iamARN string
+
+	cmd := &cobra.Command{
+		Use:     "iamidentitymapping",
+		Short:   "Get IAM identity mapping(s)",
+		Aliases: []string{"iamidentitymappings"},
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := doGetIAMIdentityMapping(p, cfg, iamARN); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		fs.StringVar(&iamARN, "arn", "", "ARN of the IAM role or user, or ID of the account (all mappings are shown if unspecified)")
+		cmdutils.AddCommonFlagsForGetCmd(fs, &chunkSize, &output)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doGetIAMIdentityMapping(p *api.ProviderConfig, cfg *api.ClusterConfig, iamARN string) error {
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+
+	ctl := eks.New(p, cfg)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	identities, err := acm.Identities()
+	if err != nil {
+		return err
+	}
+
+	if iamARN != "" {
+		matching := []*authconfigmap.Identity{}
+		for _, id := range identities {
+			if id.ARN == iamARN {
+				matching = append(matching, id)
+			}
+		}
+		if len(matching) == 0 {
+			return errors.Errorf("no mapping of %q found in cluster %q", iamARN, cfg.Metadata.Name)
+		}
+		identities = matching
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	if output == "table" {
+		addIAMIdentityMappingTableColumns(printer.(*printers.TablePrinter))
+	}
+
+	return printer.PrintObjWithKind("iamidentitymappings", identities, os.Stdout)
+}
+
+func addIAMIdentityMappingTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("KIND", func(id *authconfigmap.Identity) string {
+		return id.Kind
+	})
+	printer.AddColumn("ARN", func(id *authconfigmap.Identity) string {
+		return id.ARN
+	})
+	printer.AddColumn("USERNAME", func(id *authconfigmap.Identity) string {
+		return id.Username
+	})
+	printer.AddColumn("GROUPS", func(id *authconfigmap.Identity) string {
+		return strings.Join(id.Groups, ",")
+	})
+}
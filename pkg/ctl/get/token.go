@@ -0,0 +1,75 @@
+package get
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func getTokenCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var (
+		roleARN string
+		noCache bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Get a token for authenticating with a cluster, formatted as an ExecCredential for use in kubeconfig",
+		Run: func(_ *cobra.Command, _ []string) {
+			if err := doGetToken(p, cfg, roleARN, noCache); err != nil {
+				// the output is read by kubectl, so errors must not be written to stdout
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		fs.StringVar(&roleARN, "role-arn", "", "ARN of the IAM role to assume for generating the token")
+		fs.BoolVar(&noCache, "no-cache", false, "always generate a new token, instead of reusing a valid token cached in ~/.kube/cache/eksctl/tokens")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doGetToken(p *api.ProviderConfig, cfg *api.ClusterConfig, roleARN string, noCache bool) error {
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+
+	ctl := eks.New(p, cfg)
+
+	var cache *eks.TokenCache
+	if !noCache {
+		cache = &eks.TokenCache{Dir: eks.DefaultTokenCacheDir}
+	}
+
+	tok, err := ctl.GetToken(cfg, roleARN, cache)
+	if err != nil {
+		return err
+	}
+
+	execCredential, err := eks.FormatExecCredential(tok)
+	if err != nil {
+		return err
+	}
+	fmt.Println(execCredential)
+	return nil
+}
@@ -16,13 +16,14 @@ func scaleNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
 	p := &api.ProviderConfig{}
 	cfg := api.NewClusterConfig()
 	ng := cfg.NewNodeGroup()
+	lockOpts := &cmdutils.LockOptions{}
 
 	cmd := &cobra.Command{
 		Use:     "nodegroup",
 		Short:   "Scale a nodegroup",
 		Aliases: []string{"ng"},
 		Run: func(_ *cobra.Command, args []string) {
-			if err := doScaleNodeGroup(p, cfg, ng, cmdutils.GetNameArg(args)); err != nil {
+			if err := doScaleNodeGroup(p, cfg, ng, lockOpts, cmdutils.GetNameArg(args)); err != nil {
 				logger.Critical("%s\n", err.Error())
 				os.Exit(1)
 			}
@@ -46,13 +47,14 @@ func scaleNodeGroupCmd(g *cmdutils.Grouping) *cobra.Command {
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, true)
+	cmdutils.AddLockFlags(group, lockOpts)
 
 	group.AddTo(cmd)
 
 	return cmd
 }
 
-func doScaleNodeGroup(p *api.ProviderConfig, cfg *api.ClusterConfig, ng *api.NodeGroup, nameArg string) error {
+func doScaleNodeGroup(p *api.ProviderConfig, cfg *api.ClusterConfig, ng *api.NodeGroup, lockOpts *cmdutils.LockOptions, nameArg string) error {
 	ctl := eks.New(p, cfg)
 
 	if err := ctl.CheckAuth(); err != nil {
@@ -79,8 +81,14 @@ func doScaleNodeGroup(p *api.ProviderConfig, cfg *api.ClusterConfig, ng *api.Nod
 		return fmt.Errorf("number of nodes must be 0 or greater. Use the --nodes/-N flag")
 	}
 
+	unlock, err := cmdutils.LockCluster(ctl, cfg, lockOpts, "scale nodegroup", false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	stackManager := ctl.NewStackManager(cfg)
-	err := stackManager.ScaleNodeGroup(ng)
+	err = stackManager.ScaleNodeGroup(ng)
 	if err != nil {
 		return fmt.Errorf("failed to scale nodegroup for cluster %q, error %v", cfg.Metadata.Name, err)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/pflag"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/printers"
@@ -48,6 +49,7 @@ func updateClusterCmd(g *cmdutils.Grouping) *cobra.Command {
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, false)
+	cmdutils.AddLockFlags(group, &lockOpts)
 
 	group.AddTo(cmd)
 	return cmd
@@ -58,6 +60,17 @@ func doUpdateClusterCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		return err
 	}
 
+	if err := api.ValidateClusterVPC(cfg.VPC); err != nil {
+		return err
+	}
+	if err := api.ValidateCloudFormationExtras(cfg.CloudFormation, "cloudFormation"); err != nil {
+		return err
+	}
+	if err := api.ValidateIAMIdentityMappings(cfg.IAMIdentityMappings); err != nil {
+		return err
+	}
+	api.SetClusterVPCDefaults(cfg.VPC)
+
 	ctl := eks.New(p, cfg)
 	meta := cfg.Metadata
 
@@ -77,7 +90,7 @@ func doUpdateClusterCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 	}
 
 	if clusterConfigFile != "" {
-		logger.Warning("NOTE: config file is only used for finding cluster name and region, deep cluster configuration changes are not yet implemented")
+		logger.Warning("NOTE: config file is only used for finding cluster name and region, as well as adding VPC flow logs and syncing iamIdentityMappings, deep cluster configuration changes are not yet implemented")
 	}
 
 	currentVersion := ctl.ControlPlaneVersion()
@@ -105,6 +118,12 @@ func doUpdateClusterCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		return err
 	}
 
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "update cluster", plan)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	stackManager := ctl.NewStackManager(cfg)
 
 	stackUpdateRequired, err := stackManager.AppendNewClusterStackResource(plan)
@@ -112,6 +131,20 @@ func doUpdateClusterCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		return err
 	}
 
+	identityMappingsUpdateRequired := false
+	if cfg.IAMIdentityMappings != nil {
+		clientSet, err := ctl.NewStdClientSet(cfg)
+		if err != nil {
+			return err
+		}
+		cmdutils.LogIntendedAction(plan, "sync %d iamIdentityMappings into auth ConfigMap of cluster %q", len(cfg.IAMIdentityMappings), cfg.Metadata.Name)
+		result, err := authconfigmap.SyncIAMIdentityMappings(clientSet, cfg.IAMIdentityMappings, plan)
+		if err != nil {
+			return errors.Wrap(err, "syncing iamIdentityMappings")
+		}
+		identityMappingsUpdateRequired = result.HasChanges()
+	}
+
 	if err := ctl.ValidateExistingNodeGroupsForCompatibility(cfg, stackManager); err != nil {
 		logger.Critical("failed checking nodegroups", err.Error())
 	}
@@ -136,7 +169,7 @@ func doUpdateClusterCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg s
 		}
 	}
 
-	cmdutils.LogPlanModeWarning(plan && (stackUpdateRequired || versionUpdateRequired))
+	cmdutils.LogPlanModeWarning(plan && (stackUpdateRequired || versionUpdateRequired || identityMappingsUpdateRequired))
 
 	return nil
 }
@@ -11,6 +11,8 @@ var (
 	wait = true
 
 	clusterConfigFile string
+
+	lockOpts cmdutils.LockOptions
 )
 
 // Command will create the `create` commands
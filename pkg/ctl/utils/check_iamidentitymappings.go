@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+func checkIAMIdentityMappingsCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	cmd := &cobra.Command{
+		Use:   "check-iamidentitymappings",
+		Short: "Check that roles and users mapped in the aws-auth ConfigMap of a given cluster exist in IAM",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doCheckIAMIdentityMappings(p, cfg, cmdutils.GetNameArg(args), cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doCheckIAMIdentityMappings(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg string, cmd *cobra.Command) error {
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+	meta := cfg.Metadata
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	identities, err := acm.Identities()
+	if err != nil {
+		return err
+	}
+
+	stacks, err := ctl.NewStackManager(cfg).DescribeNodeGroupStacks()
+	if err != nil {
+		return errors.Wrap(err, "describing nodegroup stacks")
+	}
+	nodeGroupRoleARNs := []string{}
+	for _, s := range stacks {
+		collectors := map[string]outputs.Collector{
+			outputs.NodeGroupInstanceRoleARN: func(v string) error {
+				nodeGroupRoleARNs = append(nodeGroupRoleARNs, v)
+				return nil
+			},
+		}
+		if err := outputs.Collect(*s, nil, collectors); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("checking %d IAM identity mapping(s) of cluster %q", len(identities), meta.Name)
+	problems, err := iam.CheckIdentityMappings(ctl.Provider, identities, nodeGroupRoleARNs)
+	if err != nil {
+		return err
+	}
+	found := 0
+	for _, problem := range problems {
+		if problem.Unverified {
+			logger.Info(problem.String())
+			continue
+		}
+		logger.Warning(problem.String())
+		found++
+	}
+	if found > 0 {
+		return fmt.Errorf("found %d problem(s) with IAM identity mappings of cluster %q", found, meta.Name)
+	}
+
+	logger.Success("all IAM identity mappings of cluster %q are valid", meta.Name)
+	return nil
+}
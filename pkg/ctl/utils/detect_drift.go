@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func detectDriftCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "detect-drift",
+		Short: "Detect changes made to CloudFormation stacks of a given cluster outside of eksctl",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doDetectDrift(p, cfg, cmdutils.GetNameArg(args), output, cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+	return cmd
+}
+
+func doDetectDrift(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg, output string, cmd *cobra.Command) error {
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	results, err := ctl.NewStackManager(cfg).DetectStacksDrift()
+	if err != nil {
+		return err
+	}
+
+	drifted := []*manager.ResourceDrift{}
+	for _, r := range results {
+		drifted = append(drifted, r.DriftedResources...)
+	}
+
+	if output == "table" {
+		addDriftTableColumns(printer.(*printers.TablePrinter))
+		if err := printer.PrintObjWithKind("drifted resources", driftTableRows(drifted), os.Stdout); err != nil {
+			return err
+		}
+	} else {
+		if err := printer.PrintObjWithKind("stack drifts", results, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	if len(drifted) > 0 {
+		logger.Warning("%d resource(s) in stacks of cluster %q were changed outside of eksctl", len(drifted), cfg.Metadata.Name)
+	} else {
+		logger.Success("no drift detected in stacks of cluster %q", cfg.Metadata.Name)
+	}
+	return nil
+}
+
+// driftTableRow is a row in the table output, there is one row for each
+// property difference, so that resources with many changes remain readable
+type driftTableRow struct {
+	resource *manager.ResourceDrift
+	diff     *manager.PropertyDifference
+}
+
+func driftTableRows(resources []*manager.ResourceDrift) []driftTableRow {
+	rows := []driftTableRow{}
+	for _, r := range resources {
+		if len(r.PropertyDifferences) == 0 {
+			rows = append(rows, driftTableRow{resource: r, diff: &manager.PropertyDifference{}})
+			continue
+		}
+		for _, d := range r.PropertyDifferences {
+			rows = append(rows, driftTableRow{resource: r, diff: d})
+		}
+	}
+	return rows
+}
+
+func addDriftTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("STACK", func(r driftTableRow) string {
+		return r.resource.StackName
+	})
+	printer.AddColumn("RESOURCE", func(r driftTableRow) string {
+		return r.resource.LogicalResourceID
+	})
+	printer.AddColumn("TYPE", func(r driftTableRow) string {
+		return r.resource.ResourceType
+	})
+	printer.AddColumn("STATUS", func(r driftTableRow) string {
+		return r.resource.DriftStatus
+	})
+	printer.AddColumn("PROPERTY", func(r driftTableRow) string {
+		return r.diff.PropertyPath
+	})
+	printer.AddColumn("EXPECTED", func(r driftTableRow) string {
+		return r.diff.ExpectedValue
+	})
+	printer.AddColumn("ACTUAL", func(r driftTableRow) string {
+		return r.diff.ActualValue
+	})
+}
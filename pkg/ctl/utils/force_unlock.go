@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"os"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/lock"
+)
+
+func forceUnlockCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	cmd := &cobra.Command{
+		Use:   "force-unlock",
+		Short: "Remove the lock of a given cluster, which may have been left behind by an interrupted operation",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doForceUnlock(p, cfg, cmdutils.GetNameArg(args), cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		cmdutils.AddLockBackendFlag(fs, &lockOpts)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doForceUnlock(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg string, cmd *cobra.Command) error {
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+	meta := cfg.Metadata
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	backend, err := cmdutils.NewLockBackend(ctl, cfg, &lockOpts)
+	if err != nil {
+		return err
+	}
+	if backend == nil {
+		logger.Info("locking is disabled, nothing to unlock")
+		return nil
+	}
+
+	locker := &lock.Locker{Backend: backend}
+	released, err := locker.ForceRelease()
+	if err != nil {
+		return err
+	}
+	if released == nil {
+		logger.Info("cluster %q is not locked", meta.Name)
+		return nil
+	}
+	logger.Success("removed lock held by %s for %q since %s", released.Holder, released.Operation, released.Acquired.Local().Format(time.RFC3339))
+	return nil
+}
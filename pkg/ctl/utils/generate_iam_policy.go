@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+func generateIAMPolicyCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	cmd := &cobra.Command{
+		Use:   "generate-iam-policy",
+		Short: "Generate a minimal IAM policy for creating a cluster, as described by a config file or with default settings",
+		Run: func(cmd *cobra.Command, _ []string) {
+			if err := doGenerateIAMPolicy(p, cfg, cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+	})
+
+	group.InFlagSet("AWS client", func(fs *pflag.FlagSet) {
+		fs.StringVar(&p.CloudFormationRoleARN, "cfn-role-arn", "", "IAM role that will be used by CloudFormation, in which case most of the permissions are only needed by the role")
+		fs.StringVar(&p.CloudFormationTemplateBucket, "cfn-template-bucket", "", "S3 bucket that templates will be uploaded to")
+	})
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doGenerateIAMPolicy(p *api.ProviderConfig, cfg *api.ClusterConfig, cmd *cobra.Command) error {
+	ngFilter := cmdutils.NewNodeGroupFilter()
+
+	if clusterConfigFile != "" {
+		if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, "", cmd).Load(); err != nil {
+			return err
+		}
+		if err := api.ValidateClusterIAM(&cfg.IAM); err != nil {
+			return err
+		}
+	} else {
+		// 'eksctl create cluster' creates a dedicated VPC and a nodegroup by default
+		ng := cfg.NewNodeGroup()
+		ng.Name = cmdutils.NodeGroupName("", "")
+		ng.SSH.PublicKeyPath = nil
+	}
+
+	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
+		return err
+	}
+
+	policy, err := json.MarshalIndent(iam.MakePolicyDocument(iam.RequiredActions(p, cfg, cfg.NodeGroups)), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(policy))
+	return nil
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func migrateStacksCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	cmd := &cobra.Command{
+		Use:   "migrate-stacks",
+		Short: "Add current tags and outputs to CloudFormation stacks of a given cluster that were created by older versions of eksctl",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doMigrateStacks(p, cfg, cmdutils.GetNameArg(args), cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		cmdutils.AddApproveFlag(&plan, cmd, fs)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+	cmdutils.AddLockFlags(group, &lockOpts)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doMigrateStacks(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg string, cmd *cobra.Command) error {
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+	meta := cfg.Metadata
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "migrate stacks", plan)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	migrations, err := ctl.NewStackManager(cfg).MigrateStacks(plan)
+	if err != nil {
+		return err
+	}
+
+	updateRequired := false
+	failed := 0
+	for _, m := range migrations {
+		if m.NeedsUpdate() {
+			updateRequired = true
+		}
+		for _, problem := range m.Problems {
+			logger.Warning("stack %q: %s", m.StackName, problem)
+		}
+		if len(m.Problems) > 0 {
+			failed++
+		}
+	}
+
+	if updateRequired {
+		cmdutils.LogPlanModeWarning(plan)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d stack(s) of cluster %q could not be fully migrated", failed, len(migrations), meta.Name)
+	}
+	if !updateRequired {
+		logger.Info("all stacks of cluster %q are already up-to-date", meta.Name)
+	} else if !plan {
+		logger.Success("migrated all stacks of cluster %q", meta.Name)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func updateNodeGroupIngressCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var include, exclude []string
+
+	cmd := &cobra.Command{
+		Use:   "update-nodegroup-ingress",
+		Short: "Update SSH and custom ingress rules of nodegroups to match securityGroups.ingress in the config file",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doUpdateNodeGroupIngress(p, cfg, include, exclude, cmdutils.GetNameArg(args), cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		cmdutils.AddNodeGroupFilterFlags(&include, &exclude, fs)
+		cmdutils.AddApproveFlag(&plan, cmd, fs)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+	cmdutils.AddLockFlags(group, &lockOpts)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doUpdateNodeGroupIngress(p *api.ProviderConfig, cfg *api.ClusterConfig, include, exclude []string, nameArg string, cmd *cobra.Command) error {
+	if clusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	ngFilter := cmdutils.NewNodeGroupFilter()
+	if err := ngFilter.AppendGlobs(include, exclude, cfg.NodeGroups); err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+	meta := cfg.Metadata
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
+		return err
+	}
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	unlock, err := cmdutils.LockCluster(ctl, cfg, &lockOpts, "update nodegroup ingress", plan)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	stackManager := ctl.NewStackManager(cfg)
+
+	ngFilter.LogInfo(cfg.NodeGroups)
+
+	updateRequired := false
+	if err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if api.IsDisabled(ng.SecurityGroups.WithLocal) {
+			logger.Info("nodegroup %q doesn't have a local security group, skipping", ng.Name)
+			return nil
+		}
+		updated, err := stackManager.UpdateNodeGroupIngressRules(ng, plan)
+		if err != nil {
+			return errors.Wrapf(err, "updating ingress rules of nodegroup %q", ng.Name)
+		}
+		updateRequired = updateRequired || updated
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	cmdutils.LogPlanModeWarning(plan && updateRequired)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func updateProtectionCmd(g *cmdutils.Grouping) *cobra.Command {
+	p := &api.ProviderConfig{}
+	cfg := api.NewClusterConfig()
+
+	var enable bool
+
+	cmd := &cobra.Command{
+		Use:   "update-protection",
+		Short: "Enable or disable termination protection and stack policy of all CloudFormation stacks of a given cluster",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doUpdateProtection(p, cfg, enable, cmdutils.GetNameArg(args), cmd); err != nil {
+				logger.Critical("%s\n", err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	group := g.New(cmd)
+
+	group.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		fs.BoolVar(&enable, "enable", true, "whether the cluster should be protected (when config file is given, metadata.protection is used by default)")
+		cmdutils.AddApproveFlag(&plan, cmd, fs)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(group, p, false)
+
+	group.AddTo(cmd)
+
+	return cmd
+}
+
+func doUpdateProtection(p *api.ProviderConfig, cfg *api.ClusterConfig, enable bool, nameArg string, cmd *cobra.Command) error {
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
+	}
+
+	if clusterConfigFile != "" && !cmd.Flag("enable").Changed {
+		enable = cfg.Metadata.Protection
+	}
+
+	ctl := eks.New(p, cfg)
+	meta := cfg.Metadata
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	updateRequired, err := ctl.NewStackManager(cfg).UpdateProtection(enable, plan)
+	if err != nil {
+		return err
+	}
+
+	if !updateRequired {
+		logger.Info("protection of cluster %q is already up-to-date", meta.Name)
+		return nil
+	}
+
+	cmdutils.LogPlanModeWarning(plan)
+
+	if !plan {
+		if enable {
+			logger.Success("cluster %q is now protected", meta.Name)
+		} else {
+			logger.Success("cluster %q is no longer protected", meta.Name)
+		}
+	}
+	return nil
+}
@@ -10,6 +10,8 @@ var (
 	clusterConfigFile = ""
 
 	plan = true
+
+	lockOpts cmdutils.LockOptions
 )
 
 // Command will create the `utils` commands
@@ -32,6 +34,13 @@ func Command(g *cmdutils.Grouping) *cobra.Command {
 	cmd.AddCommand(updateAWSNodeCmd(g))
 	cmd.AddCommand(updateCoreDNSCmd(g))
 	cmd.AddCommand(installCoreDNSCmd(g))
+	cmd.AddCommand(updateNodeGroupIngressCmd(g))
+	cmd.AddCommand(detectDriftCmd(g))
+	cmd.AddCommand(updateProtectionCmd(g))
+	cmd.AddCommand(migrateStacksCmd(g))
+	cmd.AddCommand(forceUnlockCmd(g))
+	cmd.AddCommand(checkIAMIdentityMappingsCmd(g))
+	cmd.AddCommand(generateIAMPolicyCmd(g))
 
 	return cmd
 }
@@ -3,11 +3,13 @@ package utils
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
@@ -16,20 +18,24 @@ import (
 )
 
 var (
-	writeKubeconfigOutputPath string
-	writeKubeconfigSetContext bool
-	writeKubeconfigAutoPath   bool
+	writeKubeconfigOutputPath          string
+	writeKubeconfigSetContext          bool
+	writeKubeconfigAutoPath            bool
+	writeKubeconfigAll                 bool
+	writeKubeconfigAllRegions          bool
+	writeKubeconfigContextNameTemplate string
 )
 
 func writeKubeconfigCmd(g *cmdutils.Grouping) *cobra.Command {
 	p := &api.ProviderConfig{}
 	cfg := api.NewClusterConfig()
+	authenticator := &api.KubeconfigAuthenticator{}
 
 	cmd := &cobra.Command{
 		Use:   "write-kubeconfig",
-		Short: "Write kubeconfig file for a given cluster",
-		Run: func(_ *cobra.Command, args []string) {
-			if err := doWriteKubeconfigCmd(p, cfg, cmdutils.GetNameArg(args)); err != nil {
+		Short: "Write kubeconfig file for a given cluster, or for all clusters",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := doWriteKubeconfigCmd(p, cfg, authenticator, cmdutils.GetNameArg(args), cmd); err != nil {
 				logger.Critical("%s\n", err.Error())
 				os.Exit(1)
 			}
@@ -41,10 +47,15 @@ func writeKubeconfigCmd(g *cmdutils.Grouping) *cobra.Command {
 	group.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
 		cmdutils.AddRegionFlag(fs, p)
+		cmdutils.AddConfigFileFlag(&clusterConfigFile, fs)
+		fs.BoolVar(&writeKubeconfigAll, "all", false, "write kubeconfig for all clusters, and remove clusters that no longer exist from it (current-context is not set)")
+		fs.BoolVarP(&writeKubeconfigAllRegions, "all-regions", "A", false, "use with --all to write kubeconfig for clusters across all supported regions of the partition")
 	})
 
 	group.InFlagSet("Output kubeconfig", func(fs *pflag.FlagSet) {
 		cmdutils.AddCommonFlagsForKubeconfig(fs, &writeKubeconfigOutputPath, &writeKubeconfigSetContext, &writeKubeconfigAutoPath, "<name>")
+		fs.StringVar(&writeKubeconfigContextNameTemplate, "context-name-template", kubeconfig.DefaultContextNameTemplate, "template of context names, fields are {{.Username}}, {{.ClusterName}} and {{.Region}}")
+		cmdutils.AddKubeconfigAuthenticatorFlags(fs, authenticator)
 	})
 
 	cmdutils.AddCommonFlagsForAWS(group, p, false)
@@ -53,23 +64,31 @@ func writeKubeconfigCmd(g *cmdutils.Grouping) *cobra.Command {
 	return cmd
 }
 
-func doWriteKubeconfigCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg string) error {
-	ctl := eks.New(p, cfg)
-
-	if err := ctl.CheckAuth(); err != nil {
+func doWriteKubeconfigCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, authenticator *api.KubeconfigAuthenticator, nameArg string, cmd *cobra.Command) error {
+	if _, err := kubeconfig.ContextName(writeKubeconfigContextNameTemplate, kubeconfig.ContextNameData{Username: "user", ClusterName: "cluster", Region: "region"}); err != nil {
 		return err
 	}
 
-	if cfg.Metadata.Name != "" && nameArg != "" {
-		return cmdutils.ErrNameFlagAndArg(cfg.Metadata.Name, nameArg)
+	if writeKubeconfigAll {
+		return doWriteAllKubeconfigs(p, cfg, authenticator, nameArg, cmd)
+	}
+
+	if writeKubeconfigAllRegions {
+		return fmt.Errorf("--all-regions can only be used with --all")
 	}
 
-	if nameArg != "" {
-		cfg.Metadata.Name = nameArg
+	if err := cmdutils.NewMetadataLoader(p, cfg, clusterConfigFile, nameArg, cmd).Load(); err != nil {
+		return err
 	}
 
-	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet("--name")
+	if err := useKubeconfigAuthenticatorFlags(cfg, authenticator, cmd); err != nil {
+		return err
+	}
+
+	ctl := eks.New(p, cfg)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
 	}
 
 	if writeKubeconfigAutoPath {
@@ -79,21 +98,151 @@ func doWriteKubeconfigCmd(p *api.ProviderConfig, cfg *api.ClusterConfig, nameArg
 		writeKubeconfigOutputPath = kubeconfig.AutoPath(cfg.Metadata.Name)
 	}
 
-	if err := ctl.GetCredentials(cfg); err != nil {
+	client, err := newKubeconfigClient(ctl, cfg)
+	if err != nil {
 		return err
 	}
 
-	client, err := ctl.NewClient(cfg, false)
+	filename, err := kubeconfig.Write(writeKubeconfigOutputPath, *client.Config, writeKubeconfigSetContext)
 	if err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+
+	logger.Success("saved kubeconfig as %q", filename)
+
+	return nil
+}
+
+// doWriteAllKubeconfigs merges a context for each of the clusters into kubeconfig, and
+// removes the clusters that no longer exist in the regions that were listed
+func doWriteAllKubeconfigs(p *api.ProviderConfig, cfg *api.ClusterConfig, authenticator *api.KubeconfigAuthenticator, nameArg string, cmd *cobra.Command) error {
+	if cfg.Metadata.Name != "" || nameArg != "" {
+		return fmt.Errorf("--all is for writing kubeconfig for all clusters, it must be used without cluster name flag/argument")
+	}
+	if clusterConfigFile != "" {
+		return cmdutils.ErrCannotUseWithConfigFile("--all")
+	}
+	if writeKubeconfigAutoPath {
+		return fmt.Errorf("--all and --auto-kubeconfig %s", cmdutils.IncompatibleFlags)
+	}
+
+	regionGiven := p.Region != ""
+	if err := useKubeconfigAuthenticatorFlags(cfg, authenticator, cmd); err != nil {
 		return err
 	}
 
-	filename, err := kubeconfig.Write(writeKubeconfigOutputPath, *client.Config, writeKubeconfigSetContext)
+	ctl := eks.New(p, cfg)
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(p)
+	}
+
+	if regionGiven && writeKubeconfigAllRegions {
+		logger.Warning("--region=%s is ignored, as --all-regions is given", p.Region)
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	clusters, err := ctl.ListClusterMetas(100, writeKubeconfigAllRegions)
+	if err != nil {
+		return err
+	}
+
+	regions := []string{p.Region}
+	if writeKubeconfigAllRegions {
+		regions = api.SupportedRegionsInPartition(api.Partition(p.Region))
+	}
+
+	newConfig := clientcmdapi.NewConfig()
+	regionalProviders := map[string]*eks.ClusterProvider{p.Region: ctl}
+	written := 0
+	for _, cl := range clusters {
+		clusterCfg := api.NewClusterConfig()
+		clusterCfg.Metadata.Name = cl.Name
+		clusterCfg.Metadata.Region = cl.Region
+		clusterCfg.Kubeconfig = cfg.Kubeconfig
+
+		regionalProvider, ok := regionalProviders[cl.Region]
+		if !ok {
+			regionalProvider = eks.New(&api.ProviderConfig{
+				Region:      cl.Region,
+				Profile:     p.Profile,
+				WaitTimeout: p.WaitTimeout,
+			}, nil)
+			if err := regionalProvider.CheckAuth(); err != nil {
+				return err
+			}
+			regionalProviders[cl.Region] = regionalProvider
+		}
+
+		client, err := newKubeconfigClient(regionalProvider, clusterCfg)
+		if err != nil {
+			// clusters that are not active yet are skipped, but they are not removed either
+			logger.Warning("skipping cluster %s: %s", cl.LogString(), err.Error())
+			continue
+		}
+		kubeconfig.Merge(newConfig, client.Config)
+		written++
+	}
+
+	filename, pruned, err := kubeconfig.WriteAndPrune(writeKubeconfigOutputPath, *newConfig, false, regions, clusters)
 	if err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
 	}
 
-	logger.Success("saved kubeconfig as %q", filename)
+	for _, cl := range pruned {
+		logger.Info("removed cluster %s from kubeconfig, as it no longer exists", cl.LogString())
+	}
+	logger.Success("saved kubeconfig for %d cluster(s) in %s as %q", written, strings.Join(regions, ", "), filename)
 
 	return nil
 }
+
+// newKubeconfigClient gets credentials of the cluster and creates client configuration
+// for it, where the context is named according to --context-name-template
+func newKubeconfigClient(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) (*eks.Client, error) {
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return nil, err
+	}
+
+	client, err := ctl.NewClient(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName, err := kubeconfig.ContextName(writeKubeconfigContextNameTemplate, kubeconfig.ContextNameData{
+		Username:    ctl.GetUsername(),
+		ClusterName: cfg.Metadata.Name,
+		Region:      cfg.Metadata.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig.RenameContext(client.Config, contextName)
+	client.ContextName = contextName
+
+	return client, nil
+}
+
+// useKubeconfigAuthenticatorFlags sets kubeconfig.authenticator from flags,
+// unless a config file is used, where it should be set instead
+func useKubeconfigAuthenticatorFlags(cfg *api.ClusterConfig, authenticator *api.KubeconfigAuthenticator, cmd *cobra.Command) error {
+	changed := false
+	for _, f := range []string{"authenticator", "authenticator-role-arn", "authenticator-env"} {
+		if cmd.Flag(f).Changed {
+			if clusterConfigFile != "" {
+				return cmdutils.ErrCannotUseWithConfigFile(fmt.Sprintf("--%s", f))
+			}
+			changed = true
+		}
+	}
+	if changed {
+		cfg.Kubeconfig = &api.ClusterKubeconfig{Authenticator: authenticator}
+	}
+	if cfg.Kubeconfig == nil {
+		return nil
+	}
+	return api.ValidateKubeconfigAuthenticator(cfg.Kubeconfig.Authenticator, "kubeconfig.authenticator")
+}
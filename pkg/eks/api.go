@@ -33,8 +33,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	awseks "github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 )
@@ -57,6 +63,9 @@ type ProviderServices struct {
 	iam  iamiface.IAMAPI
 
 	cloudtrail cloudtrailiface.CloudTrailAPI
+	elb        elbiface.ELBAPI
+	elbv2      elbv2iface.ELBV2API
+	s3         s3iface.S3API
 }
 
 // CloudFormation returns a representation of the CloudFormation API
@@ -80,6 +89,15 @@ func (p ProviderServices) IAM() iamiface.IAMAPI { return p.iam }
 // CloudTrail returns a representation of the CloudTrail API
 func (p ProviderServices) CloudTrail() cloudtrailiface.CloudTrailAPI { return p.cloudtrail }
 
+// ELB returns a representation of the ELB API
+func (p ProviderServices) ELB() elbiface.ELBAPI { return p.elb }
+
+// ELBV2 returns a representation of the ELBV2 API
+func (p ProviderServices) ELBV2() elbv2iface.ELBV2API { return p.elbv2 }
+
+// S3 returns a representation of the S3 API
+func (p ProviderServices) S3() s3iface.S3API { return p.s3 }
+
 // Region returns provider-level region setting
 func (p ProviderServices) Region() string { return p.spec.Region }
 
@@ -89,9 +107,18 @@ func (p ProviderServices) Profile() string { return p.spec.Profile }
 // WaitTimeout returns provider-level duration after which any wait operation has to timeout
 func (p ProviderServices) WaitTimeout() time.Duration { return p.spec.WaitTimeout }
 
+// StackEventsFormat returns provider-level format of CloudFormation stack events
+func (p ProviderServices) StackEventsFormat() string { return p.spec.StackEvents }
+
+// CloudFormationTemplateBucket returns, if any, the S3 bucket where templates are uploaded to
+func (p ProviderServices) CloudFormationTemplateBucket() string {
+	return p.spec.CloudFormationTemplateBucket
+}
+
 // ProviderStatus stores information about the used IAM role and the resulting session
 type ProviderStatus struct {
 	iamRoleARN        string
+	session           *session.Session
 	sessionCreds      *credentials.Credentials
 	cachedClusterInfo *awseks.Cluster
 }
@@ -122,8 +149,12 @@ func New(spec *api.ProviderConfig, clusterSpec *api.ClusterConfig) *ClusterProvi
 	)
 	provider.iam = iam.New(s)
 	provider.cloudtrail = cloudtrail.New(s)
+	provider.elb = elb.New(s)
+	provider.elbv2 = elbv2.New(s)
+	provider.s3 = s3.New(s)
 
 	c.Status = &ProviderStatus{
+		session:      s,
 		sessionCreds: s.Config.Credentials,
 	}
 
@@ -153,6 +184,19 @@ func New(spec *api.ProviderConfig, clusterSpec *api.ClusterConfig) *ClusterProvi
 		logger.Debug("Setting CloudTrail endpoint to %s", endpoint)
 		provider.cloudtrail = cloudtrail.New(s, s.Config.Copy().WithEndpoint(endpoint))
 	}
+	if endpoint, ok := os.LookupEnv("AWS_ELB_ENDPOINT"); ok {
+		logger.Debug("Setting ELB endpoint to %s", endpoint)
+		provider.elb = elb.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	}
+	if endpoint, ok := os.LookupEnv("AWS_ELBV2_ENDPOINT"); ok {
+		logger.Debug("Setting ELBV2 endpoint to %s", endpoint)
+		provider.elbv2 = elbv2.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	}
+	if endpoint, ok := os.LookupEnv("AWS_S3_ENDPOINT"); ok {
+		logger.Debug("Setting S3 endpoint to %s", endpoint)
+		// path-style addressing is what most S3-compatible stand-ins support
+		provider.s3 = s3.New(s, s.Config.Copy().WithEndpoint(endpoint).WithS3ForcePathStyle(true))
+	}
 
 	if clusterSpec != nil {
 		clusterSpec.Metadata.Region = c.Provider.Region()
@@ -233,6 +277,11 @@ func (c *ClusterProvider) CheckAuth() error {
 
 // EnsureAMI ensures that the node AMI is set and is available
 func (c *ClusterProvider) EnsureAMI(version string, ng *api.NodeGroup) error {
+	if ng.AMI == ami.ResolverStatic && api.Partition(c.Provider.Region()) != api.PartitionAWS {
+		// static AMIs are only available for the commercial partition
+		logger.Debug("using auto resolver instead of static resolver for AMIs in region %s", c.Provider.Region())
+		ng.AMI = ami.ResolverAuto
+	}
 	if ng.AMI == ami.ResolverAuto {
 		ami.DefaultResolvers = []ami.Resolver{ami.NewAutoResolver(c.Provider.EC2())}
 	}
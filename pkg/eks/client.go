@@ -28,10 +28,11 @@ type Client struct {
 }
 
 // NewClient creates a new client config, if withEmbeddedToken is true
-// it will embed the STS token, otherwise it will use authenticator exec plugin
-// and ensures that AWS_PROFILE environment variable gets set also
+// it will embed the STS token, otherwise it will use authenticator exec plugin,
+// as configured in spec.Kubeconfig.Authenticator or detected, and ensures that
+// AWS_PROFILE environment variable gets set also
 func (c *ClusterProvider) NewClient(spec *api.ClusterConfig, withEmbeddedToken bool) (*Client, error) {
-	clientConfig, _, contextName := kubeconfig.New(spec, c.getUsername(), "")
+	clientConfig, _, contextName := kubeconfig.New(spec, c.GetUsername(), "")
 
 	config := &Client{
 		Config:      clientConfig,
@@ -41,7 +42,8 @@ func (c *ClusterProvider) NewClient(spec *api.ClusterConfig, withEmbeddedToken b
 	return config.new(spec, withEmbeddedToken, c.Provider.STS(), c.Provider.Profile())
 }
 
-func (c *ClusterProvider) getUsername() string {
+// GetUsername returns the name of the IAM role or user of the current session, as used in context names
+func (c *ClusterProvider) GetUsername() string {
 	usernameParts := strings.Split(c.Status.iamRoleARN, "/")
 	if len(usernameParts) > 1 {
 		return usernameParts[len(usernameParts)-1]
@@ -55,7 +57,14 @@ func (c *Client) new(spec *api.ClusterConfig, withEmbeddedToken bool, stsClient
 			return nil, err
 		}
 	} else {
-		kubeconfig.AppendAuthenticator(c.Config, spec, utils.DetectAuthenticator(), profile)
+		authenticator := &api.KubeconfigAuthenticator{}
+		if spec.Kubeconfig != nil && spec.Kubeconfig.Authenticator != nil {
+			authenticator = spec.Kubeconfig.Authenticator.DeepCopy()
+		}
+		if authenticator.Command == "" {
+			authenticator.Command = utils.DetectAuthenticator()
+		}
+		kubeconfig.AppendAuthenticator(c.Config, spec, authenticator, profile)
 	}
 
 	rawConfig, err := clientcmd.NewDefaultClientConfig(*c.Config, &clientcmd.ConfigOverrides{}).ClientConfig()
@@ -8,6 +8,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	. "github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 )
 
 var _ = Describe("eks auth helpers", func() {
@@ -38,6 +39,12 @@ var _ = Describe("eks auth helpers", func() {
 						Endpoint:                 "https://TEST.aws",
 						CertificateAuthorityData: []byte("123"),
 					},
+					// authenticator is set, as otherwise it's detected from what's installed
+					Kubeconfig: &api.ClusterKubeconfig{
+						Authenticator: &api.KubeconfigAuthenticator{
+							Command: kubeconfig.AWSIAMAuthenticator,
+						},
+					},
 				}
 
 				It("should create config with authenticator", func() {
@@ -70,7 +77,7 @@ var _ = Describe("eks auth helpers", func() {
 					Expect(k.AuthInfos[ctx].Token).To(BeEmpty())
 					Expect(k.AuthInfos[ctx].Exec).To(Not(BeNil()))
 
-					Expect(k.AuthInfos[ctx].Exec.Command).To(MatchRegexp("(heptio-authenticator-aws|aws-iam-authenticator)"))
+					Expect(k.AuthInfos[ctx].Exec.Command).To(Equal(kubeconfig.AWSIAMAuthenticator))
 
 					Expect(strings.Join(k.AuthInfos[ctx].Exec.Args, " ")).To(Equal("token -i auth-test-cluster"))
 
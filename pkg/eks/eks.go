@@ -18,6 +18,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/printers"
 	"github.com/weaveworks/eksctl/pkg/utils/waiters"
 	"github.com/weaveworks/eksctl/pkg/vpc"
@@ -111,6 +112,20 @@ func (c *ClusterProvider) GetClusterVPC(spec *api.ClusterConfig) error {
 	return vpc.UseFromCluster(c.Provider, stack, spec)
 }
 
+// UseExistingClusterStack retrieves the VPC configuration from a cluster stack
+// that was already created, along with cluster endpoint and the certificate
+// authority data, which are otherwise collected from outputs of the cluster
+// stack when it is being created
+func (c *ClusterProvider) UseExistingClusterStack(stack *manager.Stack, spec *api.ClusterConfig) error {
+	if err := vpc.UseFromCluster(c.Provider, stack, spec); err != nil {
+		return errors.Wrapf(err, "using VPC of existing cluster stack %q", *stack.StackName)
+	}
+	if err := c.GetCredentials(spec); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", spec.Metadata.Name)
+	}
+	return nil
+}
+
 // ListClusters display details of all the EKS cluster in your account
 func (c *ClusterProvider) ListClusters(clusterName string, chunkSize int, output string, eachRegion bool) error {
 	// NOTE: this needs to be reworked in the future so that the functionality
@@ -131,13 +146,23 @@ func (c *ClusterProvider) ListClusters(clusterName string, chunkSize int, output
 	if output == "table" {
 		addListTableColumns(printer.(*printers.TablePrinter))
 	}
-	allClusters := []*api.ClusterMeta{}
-	if err := c.doListClusters(int64(chunkSize), printer, &allClusters, eachRegion); err != nil {
+	allClusters, err := c.ListClusterMetas(chunkSize, eachRegion)
+	if err != nil {
 		return err
 	}
 	return printer.PrintObjWithKind("clusters", allClusters, os.Stdout)
 }
 
+// ListClusterMetas returns names and regions of all the EKS clusters in your account,
+// either in the current region or in each of supported regions
+func (c *ClusterProvider) ListClusterMetas(chunkSize int, eachRegion bool) ([]*api.ClusterMeta, error) {
+	allClusters := []*api.ClusterMeta{}
+	if err := c.doListClusters(int64(chunkSize), &allClusters, eachRegion); err != nil {
+		return nil, err
+	}
+	return allClusters, nil
+}
+
 func (c *ClusterProvider) getClustersRequest(chunkSize int64, nextToken string) ([]*string, *string, error) {
 	input := &awseks.ListClustersInput{MaxResults: &chunkSize}
 	if nextToken != "" {
@@ -150,16 +175,16 @@ func (c *ClusterProvider) getClustersRequest(chunkSize int64, nextToken string)
 	return output.Clusters, output.NextToken, nil
 }
 
-func (c *ClusterProvider) doListClusters(chunkSize int64, printer printers.OutputPrinter, allClusters *[]*api.ClusterMeta, eachRegion bool) error {
+func (c *ClusterProvider) doListClusters(chunkSize int64, allClusters *[]*api.ClusterMeta, eachRegion bool) error {
 	if eachRegion {
 		// reset region and re-create the client, then make a recursive call
-		for _, region := range api.SupportedRegions() {
+		for _, region := range api.SupportedRegionsInPartition(api.Partition(c.Provider.Region())) {
 			spec := &api.ProviderConfig{
 				Region:      region,
 				Profile:     c.Provider.Profile(),
 				WaitTimeout: c.Provider.WaitTimeout(),
 			}
-			if err := New(spec, nil).doListClusters(chunkSize, printer, allClusters, false); err != nil {
+			if err := New(spec, nil).doListClusters(chunkSize, allClusters, false); err != nil {
 				return err
 			}
 		}
@@ -12,6 +12,11 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 	. "github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/testutils"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
@@ -68,43 +73,18 @@ var _ = Describe("EKS API wrapper", func() {
 					Expect(p.MockEKS().AssertNumberOfCalls(GinkgoT(), "DescribeCluster", 1)).To(BeTrue())
 				})
 
-				It("should not call AWS CFN ListStacksPages", func() {
-					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "ListStacksPages", 0)).To(BeTrue())
+				It("should not call AWS CFN DescribeStacksPages", func() {
+					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacksPages", 0)).To(BeTrue())
 				})
 			})
 
 			Context("and debug log level", func() {
 
 				BeforeEach(func() {
-					expectedStatusFilter := []string{
-						"CREATE_IN_PROGRESS",
-						"CREATE_FAILED",
-						"CREATE_COMPLETE",
-						"ROLLBACK_IN_PROGRESS",
-						"ROLLBACK_FAILED",
-						"ROLLBACK_COMPLETE",
-						"DELETE_IN_PROGRESS",
-						"DELETE_FAILED",
-						"UPDATE_IN_PROGRESS",
-						"UPDATE_COMPLETE_CLEANUP_IN_PROGRESS",
-						"UPDATE_COMPLETE",
-						"UPDATE_ROLLBACK_IN_PROGRESS",
-						"UPDATE_ROLLBACK_FAILED",
-						"UPDATE_ROLLBACK_COMPLETE_CLEANUP_IN_PROGRESS",
-						"UPDATE_ROLLBACK_COMPLETE",
-						"REVIEW_IN_PROGRESS",
-					}
-
 					logger.Level = 4
 
-					p.MockCloudFormation().On("ListStacksPages", mock.MatchedBy(func(input *cfn.ListStacksInput) bool {
-						matches := 0
-						for i := range input.StackStatusFilter {
-							if *input.StackStatusFilter[i] == expectedStatusFilter[i] {
-								matches++
-							}
-						}
-						return matches == len(expectedStatusFilter)
+					p.MockCloudFormation().On("DescribeStacksPages", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
+						return input.StackName == nil
 					}), mock.Anything).Return(nil)
 				})
 
@@ -120,8 +100,8 @@ var _ = Describe("EKS API wrapper", func() {
 					Expect(p.MockEKS().AssertNumberOfCalls(GinkgoT(), "DescribeCluster", 1)).To(BeTrue())
 				})
 
-				It("should have called AWS CFN ListStacksPages", func() {
-					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "ListStacksPages", 1)).To(BeTrue())
+				It("should have called AWS CFN DescribeStacksPages", func() {
+					Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacksPages", 1)).To(BeTrue())
 				})
 			})
 		})
@@ -172,8 +152,8 @@ var _ = Describe("EKS API wrapper", func() {
 				Expect(p.MockEKS().AssertNumberOfCalls(GinkgoT(), "DescribeCluster", 1)).To(BeTrue())
 			})
 
-			It("should not call AWS CFN ListStacksPages", func() {
-				Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "ListStacksPages", 0)).To(BeTrue())
+			It("should not call AWS CFN DescribeStacksPages", func() {
+				Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacksPages", 0)).To(BeTrue())
 			})
 
 			It("the output should equal the golden file singlecluster_deleting.golden", func() {
@@ -276,4 +256,82 @@ var _ = Describe("EKS API wrapper", func() {
 		})
 
 	})
+
+	Describe("UseExistingClusterStack", func() {
+		var (
+			cfg      *api.ClusterConfig
+			existing *manager.ExistingStacks
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			c = &ClusterProvider{
+				Provider: p,
+				Status:   &ProviderStatus{},
+			}
+
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "test-cluster"
+			cfg.VPC.Subnets = &api.ClusterSubnets{
+				Public: map[string]api.Network{
+					"us-west-2a": {ID: "subnet-1"},
+				},
+			}
+			ng := cfg.NewNodeGroup()
+			ng.Name = "ng-1"
+			ng.AMI = "ami-123"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+			ng.InstanceType = "m5.large"
+			ng.SSH.PublicKeyPath = nil
+
+			cluster := testutils.NewFakeCluster(cfg.Metadata.Name, awseks.ClusterStatusActive)
+			cluster.Endpoint = aws.String("https://test-cluster.eks.amazonaws.com")
+			cluster.CertificateAuthority = &awseks.Certificate{Data: aws.String("dGVzdC1jYQ==")}
+			p.MockEKS().On("DescribeCluster", mock.MatchedBy(func(input *awseks.DescribeClusterInput) bool {
+				return *input.Name == cfg.Metadata.Name
+			})).Return(&awseks.DescribeClusterOutput{Cluster: cluster}, nil)
+
+			existing = &manager.ExistingStacks{
+				Cluster: &manager.Stack{
+					StackName:   aws.String("eksctl-test-cluster-cluster"),
+					StackStatus: aws.String(cfn.StackStatusCreateComplete),
+					Outputs: []*cfn.Output{
+						{OutputKey: aws.String(outputs.ClusterVPC), OutputValue: aws.String("vpc-1234")},
+						{OutputKey: aws.String(outputs.ClusterSecurityGroup), OutputValue: aws.String("sg-1234")},
+					},
+				},
+				NodeGroups: map[string]*manager.Stack{},
+			}
+		})
+
+		It("should load credentials, so that a missing nodegroup can be created when resuming", func() {
+			Expect(c.UseExistingClusterStack(existing.Cluster, cfg)).To(Succeed())
+
+			Expect(cfg.VPC.ID).To(Equal("vpc-1234"))
+			Expect(cfg.VPC.SecurityGroup).To(Equal("sg-1234"))
+			Expect(cfg.Status).NotTo(BeNil())
+			Expect(cfg.Status.Endpoint).To(Equal("https://test-cluster.eks.amazonaws.com"))
+			Expect(string(cfg.Status.CertificateAuthorityData)).To(Equal("test-ca"))
+
+			stackManager := c.NewStackManager(cfg)
+			tasks, err := stackManager.NewTasksToResumeClusterWithNodeGroups(existing, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks.Describe()).To(Equal(`1 task: { create nodegroup "ng-1" }`))
+
+			// this is what the nodegroup task does before creating the stack
+			ngrs := builder.NewNodeGroupResourceSet(p, cfg, "eksctl-test-cluster-cluster", cfg.NodeGroups[0])
+			Expect(ngrs.AddAllResources()).To(Succeed())
+		})
+
+		It("should fail when the cluster is not active", func() {
+			p.MockEKS().ExpectedCalls = nil
+			p.MockEKS().On("DescribeCluster", mock.Anything).Return(&awseks.DescribeClusterOutput{
+				Cluster: testutils.NewFakeCluster(cfg.Metadata.Name, awseks.ClusterStatusFailed),
+			}, nil)
+
+			err := c.UseExistingClusterStack(existing.Cluster, cfg)
+			Expect(err).To(MatchError(ContainSubstring(`getting credentials for cluster "test-cluster"`)))
+			Expect(cfg.Status).To(BeNil())
+		})
+	})
 })
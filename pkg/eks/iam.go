@@ -0,0 +1,23 @@
+package eks
+
+import (
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+// CheckIAMPermissions simulates policies of the IAM user or role of the current session
+// for the given actions, and returns the actions that are not allowed; CheckAuth must be
+// called first, and no actions are returned for identities that cannot be simulated
+func (c *ClusterProvider) CheckIAMPermissions(actions []string) ([]*iam.MissingPermission, error) {
+	principalARN, err := iam.SimulationPrincipalARN(c.Provider, c.Status.iamRoleARN)
+	if err != nil {
+		return nil, err
+	}
+	if principalARN == "" {
+		logger.Debug("policies of %q cannot be simulated, IAM permissions will not be checked", c.Status.iamRoleARN)
+		return nil, nil
+	}
+	logger.Debug("simulating policies of %q for %d action(s)", principalARN, len(actions))
+	return iam.MissingPermissions(c.Provider, principalARN, actions)
+}
@@ -8,3 +8,6 @@ import _ "github.com/vektra/mockery" // used for testing
 //go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/sts/stsiface -name=STSAPI -output=./
 //go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/iam/iamiface -name=IAMAPI -output=./
 //go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface -name=CloudTrailAPI -output=./
+//go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/elb/elbiface -name=ELBAPI -output=./
+//go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/elbv2/elbv2iface -name=ELBV2API -output=./
+//go:generate ${GOPATH}/bin/mockery -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/s3/s3iface -name=S3API -output=./
@@ -0,0 +1,140 @@
+package eks
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/kris-nova/logger"
+	"github.com/kubernetes-sigs/aws-iam-authenticator/pkg/token"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// DefaultTokenCacheDir is where tokens are cached by default
+var DefaultTokenCacheDir = filepath.Join(clientcmd.RecommendedConfigDir, "cache", "eksctl", "tokens")
+
+// tokenExpiryMargin is how long a cached token has to remain valid for it to be reused
+const tokenExpiryMargin = time.Minute
+
+// TokenCache stores tokens in files, so that tokens can be reused until they expire
+type TokenCache struct {
+	Dir string
+}
+
+// TokenCacheKey returns the key under which a token for given cluster is stored; the
+// access key ID identifies the credentials, as these can change without a change of the
+// profile, e.g. via environment variables; profile, role and access key ID are hashed,
+// as these may contain characters that cannot be used in a file name, and shouldn't be
+// stored in plain text
+func TokenCacheKey(clusterName, region, profile, roleARN, accessKeyID string) string {
+	sum := sha256.Sum256([]byte(profile + "\n" + roleARN + "\n" + accessKeyID))
+	return fmt.Sprintf("%s.%s.%x", clusterName, region, sum[:8])
+}
+
+func (tc *TokenCache) path(key string) string {
+	return filepath.Join(tc.Dir, key+".json")
+}
+
+// Get returns the cached token for the given key, unless it's missing or about to expire
+func (tc *TokenCache) Get(key string) (*token.Token, bool) {
+	data, err := ioutil.ReadFile(tc.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Debug("ignoring error reading cached token: %s", err.Error())
+		}
+		return nil, false
+	}
+	tok := &token.Token{}
+	if err := json.Unmarshal(data, tok); err != nil {
+		logger.Debug("ignoring malformed cached token: %s", err.Error())
+		return nil, false
+	}
+	if tok.Token == "" || time.Now().Add(tokenExpiryMargin).After(tok.Expiration) {
+		return nil, false
+	}
+	return tok, true
+}
+
+// Put stores the token under the given key, the file is only readable by its owner
+func (tc *TokenCache) Put(key string, tok token.Token) error {
+	if err := os.MkdirAll(tc.Dir, 0700); err != nil {
+		return errors.Wrapf(err, "creating token cache directory %q", tc.Dir)
+	}
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(tc.path(key), data, 0600); err != nil {
+		return errors.Wrap(err, "writing cached token")
+	}
+	return nil
+}
+
+// GetToken generates a bearer token for the cluster by presigning an STS request, when roleARN
+// is set the role is assumed first; if cache is non-nil, a cached token is returned while valid
+func (c *ClusterProvider) GetToken(spec *api.ClusterConfig, roleARN string, cache *TokenCache) (*token.Token, error) {
+	var key string
+	if cache != nil {
+		creds, err := c.Status.sessionCreds.Get()
+		if err != nil {
+			// the token cannot be generated either, which reports the error
+			logger.Debug("not using token cache, as credentials cannot be resolved: %s", err.Error())
+			cache = nil
+		} else {
+			key = TokenCacheKey(spec.Metadata.Name, spec.Metadata.Region, c.Provider.Profile(), roleARN, creds.AccessKeyID)
+			if tok, ok := cache.Get(key); ok {
+				logger.Debug("using cached token for cluster %q", spec.Metadata.Name)
+				return tok, nil
+			}
+		}
+	}
+
+	gen, err := token.NewGenerator(false, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get token generator")
+	}
+
+	stsAPI, ok := c.Provider.STS().(*sts.STS)
+	if !ok {
+		return nil, fmt.Errorf("unexpected STS client type %T", c.Provider.STS())
+	}
+	if roleARN != "" {
+		// the role is assumed with the same client, so that a custom endpoint is respected
+		creds := stscreds.NewCredentialsWithClient(stsAPI, roleARN)
+		stsAPI = sts.New(c.Status.session, stsAPI.Config.Copy().WithCredentials(creds))
+	}
+
+	tok, err := gen.GetWithSTS(spec.Metadata.Name, stsAPI)
+	if err != nil {
+		if roleARN != "" {
+			return nil, errors.Wrapf(err, "could not get token using role %q", roleARN)
+		}
+		return nil, errors.Wrap(err, "could not get token")
+	}
+
+	if cache != nil {
+		if err := cache.Put(key, tok); err != nil {
+			logger.Debug("ignoring error caching token: %s", err.Error())
+		}
+	}
+	return &tok, nil
+}
+
+// FormatExecCredential formats the token as an ExecCredential, as expected from
+// client-go credential plugins
+func FormatExecCredential(tok *token.Token) (string, error) {
+	gen, err := token.NewGenerator(false, false)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get token generator")
+	}
+	return gen.FormatJSON(*tok), nil
+}
@@ -0,0 +1,260 @@
+package elb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// serviceNameTag is set by Kubernetes cloud provider on load balancers it creates for services
+	serviceNameTag = "kubernetes.io/service-name"
+	// securityGroupNamePrefix is used by Kubernetes cloud provider for security groups of load balancers
+	securityGroupNamePrefix = "k8s-elb-"
+
+	// describeTagsChunkSize is the maximum number of load balancers accepted by DescribeTags
+	describeTagsChunkSize = 20
+
+	pollInterval = 10 * time.Second
+
+	// securityGroupRetryInterval is the initial delay before deleting a security group
+	// that is still in use is retried, it's doubled after each attempt up to pollInterval
+	securityGroupRetryInterval = time.Second
+
+	// errCodeDependencyViolation is returned while a security group is still in use,
+	// e.g. by network interfaces of a load balancer that was just deleted
+	errCodeDependencyViolation = "DependencyViolation"
+)
+
+func clusterTag(clusterName string) string {
+	return "kubernetes.io/cluster/" + clusterName
+}
+
+// Cleanup deletes all Services of type LoadBalancer, waits for the ELBs and
+// NLBs that Kubernetes had created for them to be deprovisioned, and deletes
+// any security groups that were left behind; it doesn't stop on the first
+// error, instead it returns errors for all resources it couldn't clean up,
+// unless services cannot be listed, as then no load balancers get deleted
+func Cleanup(provider api.ClusterProvider, clientSet kubernetes.Interface, clusterName string) []error {
+	deadline := time.Now().Add(provider.WaitTimeout())
+
+	errs, err := deleteServices(clientSet)
+	if err != nil {
+		return []error{err}
+	}
+
+	if err := waitForLoadBalancers(provider, clusterName, deadline); err != nil {
+		errs = append(errs, err)
+	}
+
+	return append(errs, deleteSecurityGroups(provider, clusterName, deadline)...)
+}
+
+// deleteServices returns errors for services it couldn't delete, or
+// an error when services cannot be listed
+func deleteServices(clientSet kubernetes.Interface) ([]error, error) {
+	services, err := clientSet.CoreV1().Services(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing services")
+	}
+
+	errs := []error{}
+	for _, s := range services.Items {
+		if s.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		logger.Info("deleting service %s/%s of type LoadBalancer", s.Namespace, s.Name)
+		if err := clientSet.CoreV1().Services(s.Namespace).Delete(s.Name, &metav1.DeleteOptions{}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "deleting service %s/%s", s.Namespace, s.Name))
+		}
+	}
+	return errs, nil
+}
+
+func waitForLoadBalancers(provider api.ClusterProvider, clusterName string, deadline time.Time) error {
+	timer := time.After(time.Until(deadline))
+	for {
+		names, err := listLoadBalancers(provider, clusterName)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		logger.Info("waiting for %d load balancer(s) to be deleted: %s", len(names), strings.Join(names, ", "))
+		select {
+		case <-time.After(pollInterval):
+		case <-timer:
+			return fmt.Errorf("timed out (after %s) waiting for load balancer(s) to be deleted: %s", provider.WaitTimeout(), strings.Join(names, ", "))
+		}
+	}
+}
+
+// listLoadBalancers returns names of classic and network load balancers
+// that were created by Kubernetes for services in the given cluster
+func listLoadBalancers(provider api.ClusterProvider, clusterName string) ([]string, error) {
+	classic, err := listClassicLoadBalancers(provider, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	v2, err := listV2LoadBalancers(provider, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return append(classic, v2...), nil
+}
+
+func isOwnedByService(clusterName string, keys []string) bool {
+	owned, service := false, false
+	for _, k := range keys {
+		switch k {
+		case clusterTag(clusterName):
+			owned = true
+		case serviceNameTag:
+			service = true
+		}
+	}
+	return owned && service
+}
+
+func listClassicLoadBalancers(provider api.ClusterProvider, clusterName string) ([]string, error) {
+	all := []string{}
+	err := provider.ELB().DescribeLoadBalancersPages(&awselb.DescribeLoadBalancersInput{}, func(p *awselb.DescribeLoadBalancersOutput, _ bool) bool {
+		for _, lb := range p.LoadBalancerDescriptions {
+			all = append(all, *lb.LoadBalancerName)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing classic load balancers")
+	}
+
+	names := []string{}
+	for len(all) > 0 {
+		n := describeTagsChunkSize
+		if len(all) < n {
+			n = len(all)
+		}
+		output, err := provider.ELB().DescribeTags(&awselb.DescribeTagsInput{
+			LoadBalancerNames: aws.StringSlice(all[:n]),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "describing tags of classic load balancers")
+		}
+		for _, d := range output.TagDescriptions {
+			keys := []string{}
+			for _, t := range d.Tags {
+				keys = append(keys, *t.Key)
+			}
+			if isOwnedByService(clusterName, keys) {
+				names = append(names, *d.LoadBalancerName)
+			}
+		}
+		all = all[n:]
+	}
+	return names, nil
+}
+
+func listV2LoadBalancers(provider api.ClusterProvider, clusterName string) ([]string, error) {
+	all := []string{}
+	namesByARN := map[string]string{}
+	err := provider.ELBV2().DescribeLoadBalancersPages(&awselbv2.DescribeLoadBalancersInput{}, func(p *awselbv2.DescribeLoadBalancersOutput, _ bool) bool {
+		for _, lb := range p.LoadBalancers {
+			all = append(all, *lb.LoadBalancerArn)
+			namesByARN[*lb.LoadBalancerArn] = *lb.LoadBalancerName
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing load balancers")
+	}
+
+	names := []string{}
+	for len(all) > 0 {
+		n := describeTagsChunkSize
+		if len(all) < n {
+			n = len(all)
+		}
+		output, err := provider.ELBV2().DescribeTags(&awselbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice(all[:n]),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "describing tags of load balancers")
+		}
+		for _, d := range output.TagDescriptions {
+			keys := []string{}
+			for _, t := range d.Tags {
+				keys = append(keys, *t.Key)
+			}
+			if isOwnedByService(clusterName, keys) {
+				names = append(names, namesByARN[*d.ResourceArn])
+			}
+		}
+		all = all[n:]
+	}
+	return names, nil
+}
+
+func deleteSecurityGroups(provider api.ClusterProvider, clusterName string, deadline time.Time) []error {
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: aws.StringSlice([]string{securityGroupNamePrefix + "*"}),
+			},
+			{
+				Name:   aws.String("tag-key"),
+				Values: aws.StringSlice([]string{clusterTag(clusterName)}),
+			},
+		},
+	}
+	output, err := provider.EC2().DescribeSecurityGroups(input)
+	if err != nil {
+		return []error{errors.Wrap(err, "describing load balancer security groups")}
+	}
+
+	errs := []error{}
+	for _, sg := range output.SecurityGroups {
+		logger.Info("deleting load balancer security group %q (%s)", *sg.GroupName, *sg.GroupId)
+		if err := deleteSecurityGroup(provider, sg, deadline); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// deleteSecurityGroup retries while the security group is still in use, as network
+// interfaces of deleted load balancers take a while to be detached
+func deleteSecurityGroup(provider api.ClusterProvider, sg *ec2.SecurityGroup, deadline time.Time) error {
+	interval := securityGroupRetryInterval
+	for {
+		_, err := provider.EC2().DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: sg.GroupId})
+		if err == nil {
+			return nil
+		}
+		awsErr, ok := err.(awserr.Error)
+		if !ok || awsErr.Code() != errCodeDependencyViolation || time.Now().Add(interval).After(deadline) {
+			return errors.Wrapf(err, "deleting security group %q (%s)", *sg.GroupName, *sg.GroupId)
+		}
+		logger.Debug("security group %q (%s) is still in use, retrying in %s", *sg.GroupName, *sg.GroupId, interval)
+		time.Sleep(interval)
+		if interval *= 2; interval > pollInterval {
+			interval = pollInterval
+		}
+	}
+}
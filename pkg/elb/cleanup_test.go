@@ -0,0 +1,209 @@
+package elb_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+	awselbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	. "github.com/weaveworks/eksctl/pkg/elb"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func newService(namespace, name string, serviceType corev1.ServiceType) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: serviceType,
+		},
+	}
+}
+
+var _ = Describe("ELB cleanup", func() {
+	var (
+		p         *mockprovider.MockProvider
+		clientSet *fake.Clientset
+		errs      []error
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		clientSet = fake.NewSimpleClientset(
+			newService("default", "web", corev1.ServiceTypeLoadBalancer),
+			newService("default", "internal", corev1.ServiceTypeClusterIP),
+			newService("kube-system", "ingress", corev1.ServiceTypeLoadBalancer),
+		)
+
+		p.MockELB().On("DescribeLoadBalancersPages", mock.Anything, mock.Anything).Return(nil)
+		p.MockELBV2().On("DescribeLoadBalancersPages", mock.Anything, mock.Anything).Return(nil)
+
+		p.MockEC2().On("DescribeSecurityGroups", mock.MatchedBy(func(input *ec2.DescribeSecurityGroupsInput) bool {
+			return *input.Filters[0].Values[0] == "k8s-elb-*" &&
+				*input.Filters[1].Values[0] == "kubernetes.io/cluster/test-cluster"
+		})).Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []*ec2.SecurityGroup{
+				{GroupId: aws.String("sg-1"), GroupName: aws.String("k8s-elb-a1")},
+				{GroupId: aws.String("sg-2"), GroupName: aws.String("k8s-elb-a2")},
+			},
+		}, nil)
+
+		p.MockEC2().On("DeleteSecurityGroup", mock.MatchedBy(func(input *ec2.DeleteSecurityGroupInput) bool {
+			return *input.GroupId == "sg-1"
+		})).Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+	})
+
+	mockDeleteSecurityGroup := func(id string) *mock.Call {
+		return p.MockEC2().On("DeleteSecurityGroup", mock.MatchedBy(func(input *ec2.DeleteSecurityGroupInput) bool {
+			return *input.GroupId == id
+		}))
+	}
+
+	JustBeforeEach(func() {
+		errs = Cleanup(p, clientSet, "test-cluster")
+	})
+
+	Context("when all security groups can be deleted", func() {
+		BeforeEach(func() {
+			mockDeleteSecurityGroup("sg-2").Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+		})
+
+		It("should delete only services of type LoadBalancer", func() {
+			services, err := clientSet.CoreV1().Services(metav1.NamespaceAll).List(metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(services.Items).To(HaveLen(1))
+			Expect(services.Items[0].Name).To(Equal("internal"))
+		})
+
+		It("should delete security groups", func() {
+			Expect(errs).To(BeEmpty())
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 2)).To(BeTrue())
+		})
+	})
+
+	Context("when a security group cannot be deleted", func() {
+		BeforeEach(func() {
+			mockDeleteSecurityGroup("sg-2").Return(nil, awserr.New("UnauthorizedOperation", "not allowed", nil))
+		})
+
+		It("should delete security groups and report the ones it couldn't delete", func() {
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 2)).To(BeTrue())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring(`deleting security group "k8s-elb-a2" (sg-2)`))
+		})
+	})
+
+	Context("when a security group is still in use", func() {
+		BeforeEach(func() {
+			mockDeleteSecurityGroup("sg-2").Return(nil, awserr.New("DependencyViolation", "resource sg-2 has a dependent object", nil)).Once()
+			mockDeleteSecurityGroup("sg-2").Return(&ec2.DeleteSecurityGroupOutput{}, nil)
+		})
+
+		It("should retry deleting it", func() {
+			Expect(errs).To(BeEmpty())
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 3)).To(BeTrue())
+		})
+	})
+
+	Context("when a security group is still in use at the timeout", func() {
+		var waitTimeout time.Duration
+
+		BeforeEach(func() {
+			waitTimeout = mockprovider.ProviderConfig.WaitTimeout
+			mockprovider.ProviderConfig.WaitTimeout = 1500 * time.Millisecond
+			mockDeleteSecurityGroup("sg-2").Return(nil, awserr.New("DependencyViolation", "resource sg-2 has a dependent object", nil))
+		})
+
+		AfterEach(func() {
+			mockprovider.ProviderConfig.WaitTimeout = waitTimeout
+		})
+
+		It("should give up and report it", func() {
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 3)).To(BeTrue())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring(`deleting security group "k8s-elb-a2" (sg-2)`))
+			Expect(errs[0].Error()).To(ContainSubstring("DependencyViolation"))
+		})
+	})
+
+	Context("when services cannot be listed", func() {
+		BeforeEach(func() {
+			clientSet.PrependReactor("list", "services", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("unauthorized")
+			})
+		})
+
+		It("should not wait for load balancers, nor delete security groups", func() {
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(Equal("listing services: unauthorized"))
+			Expect(p.MockELB().AssertNumberOfCalls(GinkgoT(), "DescribeLoadBalancersPages", 0)).To(BeTrue())
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DescribeSecurityGroups", 0)).To(BeTrue())
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 0)).To(BeTrue())
+		})
+	})
+
+	Context("when load balancers of other clusters exist", func() {
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+
+			p.MockELB().On("DescribeLoadBalancersPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(*awselb.DescribeLoadBalancersOutput, bool) bool)
+				fn(&awselb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: []*awselb.LoadBalancerDescription{
+						{LoadBalancerName: aws.String("a1")},
+					},
+				}, true)
+			}).Return(nil)
+			p.MockELB().On("DescribeTags", mock.Anything).Return(&awselb.DescribeTagsOutput{
+				TagDescriptions: []*awselb.TagDescription{{
+					LoadBalancerName: aws.String("a1"),
+					Tags: []*awselb.Tag{
+						{Key: aws.String("kubernetes.io/cluster/other-cluster"), Value: aws.String("owned")},
+						{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("default/web")},
+					},
+				}},
+			}, nil)
+
+			p.MockELBV2().On("DescribeLoadBalancersPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(*awselbv2.DescribeLoadBalancersOutput, bool) bool)
+				fn(&awselbv2.DescribeLoadBalancersOutput{
+					LoadBalancers: []*awselbv2.LoadBalancer{
+						{LoadBalancerName: aws.String("n1"), LoadBalancerArn: aws.String("arn:n1")},
+					},
+				}, true)
+			}).Return(nil)
+			p.MockELBV2().On("DescribeTags", mock.Anything).Return(&awselbv2.DescribeTagsOutput{
+				TagDescriptions: []*awselbv2.TagDescription{{
+					ResourceArn: aws.String("arn:n1"),
+					Tags: []*awselbv2.Tag{
+						{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")},
+					},
+				}},
+			}, nil)
+
+			p.MockEC2().On("DescribeSecurityGroups", mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+		})
+
+		It("should not wait for them", func() {
+			Expect(errs).To(BeEmpty())
+			Expect(p.MockELB().AssertNumberOfCalls(GinkgoT(), "DescribeTags", 1)).To(BeTrue())
+			Expect(p.MockELBV2().AssertNumberOfCalls(GinkgoT(), "DescribeTags", 1)).To(BeTrue())
+		})
+	})
+})
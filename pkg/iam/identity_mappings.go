@@ -0,0 +1,141 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+)
+
+// IdentityMappingProblem is a problem found with an entry of the auth ConfigMap
+type IdentityMappingProblem struct {
+	Kind    string
+	ARN     string
+	Problem string
+	// Unverified is set when the entry could not be checked, which is not
+	// necessarily a problem, e.g. for roles and users of other accounts
+	Unverified bool
+}
+
+func (p *IdentityMappingProblem) String() string {
+	return fmt.Sprintf("%s %q %s", p.Kind, p.ARN, p.Problem)
+}
+
+// CheckIdentityMappings verifies that mapped roles and users exist in IAM, that nodegroup
+// role mappings belong to one of the given nodegroup roles, and that ARNs that are mapped
+// more than once are mapped to the same username and groups; roles and users of accounts
+// other than the one of the caller cannot be looked up, so these are reported as unverified
+func CheckIdentityMappings(provider api.ClusterProvider, identities []*authconfigmap.Identity, nodeGroupRoleARNs []string) ([]*IdentityMappingProblem, error) {
+	problems := []*IdentityMappingProblem{}
+	addProblem := func(id *authconfigmap.Identity, format string, args ...interface{}) {
+		problems = append(problems, &IdentityMappingProblem{Kind: id.Kind, ARN: id.ARN, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	identity, err := provider.STS().GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting account of the caller")
+	}
+	accountID := aws.StringValue(identity.Account)
+
+	nodeGroupRoles := sets.NewString()
+	for _, roleARN := range nodeGroupRoleARNs {
+		// nodegroup roles are mapped without the path
+		nodeGroupRoles.Insert(authconfigmap.RoleARNWithoutPath(roleARN))
+	}
+	byARN := map[string][]*authconfigmap.Identity{}
+	arns := []string{}
+
+	for _, id := range identities {
+		if id.Kind == authconfigmap.IdentityAccount {
+			continue
+		}
+		if _, seen := byARN[id.ARN]; !seen {
+			arns = append(arns, id.ARN)
+		}
+		byARN[id.ARN] = append(byARN[id.ARN], id)
+	}
+
+	for _, iamARN := range arns {
+		mapped := byARN[iamARN]
+		id := mapped[0]
+
+		parsed, err := arn.Parse(iamARN)
+		switch {
+		case err != nil:
+			addProblem(id, "is not a valid ARN")
+		case parsed.AccountID != accountID:
+			problems = append(problems, &IdentityMappingProblem{Kind: id.Kind, ARN: id.ARN, Unverified: true,
+				Problem: fmt.Sprintf("belongs to account %q, its existence cannot be verified from account %q", parsed.AccountID, accountID)})
+		default:
+			exists, err := identityExists(provider, id, parsed)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				if grantsMasters(mapped) {
+					addProblem(id, "does not exist in IAM, but it's mapped to group %q", authconfigmap.GroupMasters)
+				} else {
+					addProblem(id, "does not exist in IAM")
+				}
+			}
+		}
+
+		for _, m := range mapped {
+			if authconfigmap.IsNodeGroupIdentity(m) && !nodeGroupRoles.Has(m.ARN) {
+				addProblem(id, "is mapped for a nodegroup, but no nodegroup stack of the cluster uses it")
+				break
+			}
+		}
+
+		for _, m := range mapped[1:] {
+			if m.Username != id.Username || !sets.NewString(m.Groups...).Equal(sets.NewString(id.Groups...)) {
+				addProblem(id, "is mapped %d times with conflicting usernames or groups", len(mapped))
+				break
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func grantsMasters(mapped []*authconfigmap.Identity) bool {
+	for _, m := range mapped {
+		if sets.NewString(m.Groups...).Has(authconfigmap.GroupMasters) {
+			return true
+		}
+	}
+	return false
+}
+
+// identityExists looks up a role or user by name, the name is the last
+// element of the ARN resource, as roles and users may have a path
+func identityExists(provider api.ClusterProvider, id *authconfigmap.Identity, parsed arn.ARN) (bool, error) {
+	parts := strings.Split(parsed.Resource, "/")
+	name := aws.String(parts[len(parts)-1])
+
+	var err error
+	switch id.Kind {
+	case authconfigmap.IdentityRole:
+		_, err = provider.IAM().GetRole(&awsiam.GetRoleInput{RoleName: name})
+	case authconfigmap.IdentityUser:
+		_, err = provider.IAM().GetUser(&awsiam.GetUserInput{UserName: name})
+	default:
+		return false, fmt.Errorf("unexpected kind of identity %q", id.Kind)
+	}
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == awsiam.ErrCodeNoSuchEntityException {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "getting %s %q", id.Kind, id.ARN)
+	}
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package iam_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	. "github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("IAM identity mapping checks", func() {
+	const (
+		nodeRole    = "arn:aws:iam::123456789012:role/eksctl-test-nodegroup-ng-1-NodeInstanceRole-1"
+		staleNodes  = "arn:aws:iam::123456789012:role/eksctl-test-nodegroup-ng-0-NodeInstanceRole-0"
+		adminsRole  = "arn:aws:iam::123456789012:role/team/admins"
+		deletedRole = "arn:aws:iam::123456789012:role/deleted"
+		aliceUser   = "arn:aws:iam::123456789012:user/alice"
+		bobUser     = "arn:aws:iam::123456789012:user/bob"
+	)
+
+	var p *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		p.MockSTS().On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("123456789012"),
+		}, nil)
+
+		existingRoles := map[string]bool{
+			"eksctl-test-nodegroup-ng-1-NodeInstanceRole-1": true,
+			"eksctl-test-nodegroup-ng-0-NodeInstanceRole-0": true,
+			"admins": true,
+		}
+		p.MockIAM().On("GetRole", mock.Anything).Return(func(input *awsiam.GetRoleInput) *awsiam.GetRoleOutput {
+			return &awsiam.GetRoleOutput{}
+		}, func(input *awsiam.GetRoleInput) error {
+			if existingRoles[*input.RoleName] {
+				return nil
+			}
+			return awserr.New(awsiam.ErrCodeNoSuchEntityException, "not found", nil)
+		})
+		p.MockIAM().On("GetUser", mock.Anything).Return(func(input *awsiam.GetUserInput) *awsiam.GetUserOutput {
+			return &awsiam.GetUserOutput{}
+		}, func(input *awsiam.GetUserInput) error {
+			if *input.UserName == "alice" {
+				return nil
+			}
+			return awserr.New(awsiam.ErrCodeNoSuchEntityException, "not found", nil)
+		})
+	})
+
+	It("should report deleted identities, stale nodegroup roles and conflicting duplicates", func() {
+		identities := []*authconfigmap.Identity{
+			{Kind: authconfigmap.IdentityRole, ARN: nodeRole, Username: authconfigmap.RoleNodeGroupUsername, Groups: authconfigmap.RoleNodeGroupGroups},
+			{Kind: authconfigmap.IdentityRole, ARN: staleNodes, Username: authconfigmap.RoleNodeGroupUsername, Groups: authconfigmap.RoleNodeGroupGroups},
+			{Kind: authconfigmap.IdentityRole, ARN: adminsRole, Username: "admin", Groups: []string{authconfigmap.GroupMasters}},
+			{Kind: authconfigmap.IdentityRole, ARN: adminsRole, Username: "admin", Groups: []string{"viewers"}},
+			{Kind: authconfigmap.IdentityRole, ARN: deletedRole, Username: "ops", Groups: []string{authconfigmap.GroupMasters}},
+			{Kind: authconfigmap.IdentityUser, ARN: aliceUser, Username: "alice"},
+			{Kind: authconfigmap.IdentityUser, ARN: aliceUser, Username: "alice"},
+			{Kind: authconfigmap.IdentityUser, ARN: bobUser, Username: "bob"},
+			{Kind: authconfigmap.IdentityAccount, ARN: "123456789012"},
+		}
+
+		problems, err := CheckIdentityMappings(p, identities, []string{nodeRole})
+		Expect(err).ToNot(HaveOccurred())
+
+		reported := []string{}
+		for _, problem := range problems {
+			reported = append(reported, problem.String())
+		}
+		Expect(reported).To(Equal([]string{
+			`role "` + staleNodes + `" is mapped for a nodegroup, but no nodegroup stack of the cluster uses it`,
+			`role "` + adminsRole + `" is mapped 2 times with conflicting usernames or groups`,
+			`role "` + deletedRole + `" does not exist in IAM, but it's mapped to group "system:masters"`,
+			`user "` + bobUser + `" does not exist in IAM`,
+		}))
+
+		Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "GetRole", 4)).To(BeTrue())
+		Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "GetUser", 2)).To(BeTrue())
+	})
+
+	It("should fail on unexpected IAM errors", func() {
+		p = mockprovider.NewMockProvider()
+		p.MockSTS().On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("123456789012"),
+		}, nil)
+		p.MockIAM().On("GetRole", mock.Anything).Return(nil, awserr.New("AccessDenied", "denied", nil))
+
+		_, err := CheckIdentityMappings(p, []*authconfigmap.Identity{
+			{Kind: authconfigmap.IdentityRole, ARN: adminsRole},
+		}, nil)
+		Expect(err).To(MatchError(ContainSubstring(`getting role "` + adminsRole + `"`)))
+	})
+
+	It("should not look up identities of other accounts, and report malformed ARNs", func() {
+		const (
+			otherAccountRole = "arn:aws:iam::210987654321:role/admins"
+			malformedRole    = "arn:aws:iam:role/typo"
+		)
+		problems, err := CheckIdentityMappings(p, []*authconfigmap.Identity{
+			{Kind: authconfigmap.IdentityRole, ARN: otherAccountRole, Username: "admin"},
+			{Kind: authconfigmap.IdentityRole, ARN: malformedRole, Username: "ops"},
+			{Kind: authconfigmap.IdentityUser, ARN: aliceUser, Username: "alice"},
+		}, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0].Unverified).To(BeTrue())
+		Expect(problems[0].String()).To(Equal(`role "` + otherAccountRole + `" belongs to account "210987654321", its existence cannot be verified from account "123456789012"`))
+		Expect(problems[1].Unverified).To(BeFalse())
+		Expect(problems[1].String()).To(Equal(`role "` + malformedRole + `" is not a valid ARN`))
+
+		Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "GetRole", 0)).To(BeTrue())
+		Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "GetUser", 1)).To(BeTrue())
+	})
+})
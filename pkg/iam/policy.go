@@ -0,0 +1,343 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var (
+	// actions of API calls that eksctl makes itself when creating a cluster
+	eksctlActions = []string{
+		"cloudformation:CreateStack",
+		"cloudformation:DeleteStack",
+		"cloudformation:DescribeStackEvents",
+		"cloudformation:DescribeStacks",
+		"cloudformation:GetTemplate",
+		"cloudformation:ListStacks",
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeImages",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcs",
+		"eks:DescribeCluster",
+	}
+
+	// actions that CloudFormation needs for resources of the cluster stack,
+	// including deletion of resources on rollback
+	clusterActions = []string{
+		"ec2:AuthorizeSecurityGroupEgress",
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateTags",
+		"ec2:DeleteSecurityGroup",
+		"ec2:DeleteTags",
+		"ec2:DescribeSecurityGroups",
+		"ec2:RevokeSecurityGroupEgress",
+		"ec2:RevokeSecurityGroupIngress",
+		"eks:CreateCluster",
+		"eks:DeleteCluster",
+		"iam:PassRole",
+	}
+
+	vpcActions = []string{
+		"ec2:AllocateAddress",
+		"ec2:AssociateRouteTable",
+		"ec2:AttachInternetGateway",
+		"ec2:CreateInternetGateway",
+		"ec2:CreateNatGateway",
+		"ec2:CreateRoute",
+		"ec2:CreateRouteTable",
+		"ec2:CreateSubnet",
+		"ec2:CreateVpc",
+		"ec2:DeleteInternetGateway",
+		"ec2:DeleteNatGateway",
+		"ec2:DeleteRoute",
+		"ec2:DeleteRouteTable",
+		"ec2:DeleteSubnet",
+		"ec2:DeleteVpc",
+		"ec2:DescribeAddresses",
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeRouteTables",
+		"ec2:DetachInternetGateway",
+		"ec2:DisassociateRouteTable",
+		"ec2:ModifySubnetAttribute",
+		"ec2:ModifyVpcAttribute",
+		"ec2:ReleaseAddress",
+	}
+
+	transitGatewayAttachmentActions = []string{
+		"ec2:CreateTransitGatewayVpcAttachment",
+		"ec2:DeleteTransitGatewayVpcAttachment",
+		"ec2:DescribeTransitGatewayVpcAttachments",
+	}
+
+	flowLogsActions = []string{
+		"ec2:CreateFlowLogs",
+		"ec2:DeleteFlowLogs",
+		"ec2:DescribeFlowLogs",
+	}
+
+	flowLogsCloudWatchLogsActions = []string{
+		"logs:CreateLogGroup",
+		"logs:DeleteLogGroup",
+		"logs:DescribeLogGroups",
+		"logs:PutRetentionPolicy",
+	}
+
+	flowLogsS3Actions = []string{
+		"logs:CreateLogDelivery",
+		"logs:DeleteLogDelivery",
+	}
+
+	roleActions = []string{
+		"iam:AttachRolePolicy",
+		"iam:CreateRole",
+		"iam:DeleteRole",
+		"iam:DeleteRolePolicy",
+		"iam:DetachRolePolicy",
+		"iam:GetRole",
+		"iam:GetRolePolicy",
+		"iam:PassRole",
+		"iam:PutRolePolicy",
+	}
+
+	instanceProfileActions = []string{
+		"iam:AddRoleToInstanceProfile",
+		"iam:CreateInstanceProfile",
+		"iam:DeleteInstanceProfile",
+		"iam:GetInstanceProfile",
+		"iam:RemoveRoleFromInstanceProfile",
+	}
+
+	// actions for uploading templates to the given bucket
+	templateBucketActions = []string{
+		"s3:DeleteObject",
+		"s3:PutObject",
+	}
+
+	// actions for uploading templates that are too large to be passed inline to
+	// the default bucket, which is created unless it exists (s3:HeadBucket is
+	// authorised by s3:ListBucket)
+	defaultTemplateBucketActions = []string{
+		"s3:CreateBucket",
+		"s3:DeleteObject",
+		"s3:ListBucket",
+		"s3:PutObject",
+		"sts:GetCallerIdentity",
+	}
+
+	// actions that CloudFormation needs for resources of nodegroup stacks
+	nodeGroupActions = []string{
+		"autoscaling:CreateAutoScalingGroup",
+		"autoscaling:DeleteAutoScalingGroup",
+		"autoscaling:DescribeAutoScalingGroups",
+		"autoscaling:DescribeScalingActivities",
+		"autoscaling:UpdateAutoScalingGroup",
+		"ec2:CreateLaunchTemplate",
+		"ec2:DeleteLaunchTemplate",
+		"ec2:DescribeLaunchTemplateVersions",
+		"ec2:DescribeLaunchTemplates",
+		"ec2:RunInstances",
+		"iam:PassRole",
+	}
+)
+
+// RequiredActions returns the IAM actions that the calling identity needs in order to create
+// a cluster with the given nodegroups; when a CloudFormation service role is used, resources
+// are created with that role, so only CloudFormation and API calls that eksctl makes itself
+// have to be allowed; resources added via cloudFormation.extraResources are not covered
+func RequiredActions(p *api.ProviderConfig, spec *api.ClusterConfig, nodeGroups []*api.NodeGroup) []string {
+	actions := sets.NewString(eksctlActions...)
+
+	if p.CloudFormationTemplateBucket != "" {
+		actions.Insert(templateBucketActions...)
+	} else {
+		// whether any template is too large is only known once it's rendered
+		actions.Insert(defaultTemplateBucketActions...)
+	}
+
+	for _, ng := range nodeGroups {
+		if ng.SSH == nil || !api.IsEnabled(ng.SSH.Allow) {
+			continue
+		}
+		actions.Insert("ec2:DescribeKeyPairs")
+		if !api.IsSetAndNonEmptyString(ng.SSH.PublicKeyName) {
+			actions.Insert("ec2:ImportKeyPair")
+		}
+	}
+
+	if p.CloudFormationRoleARN != "" {
+		actions.Insert("iam:PassRole")
+		return actions.List()
+	}
+
+	actions.Insert(clusterActions...)
+
+	if createsVPC(spec) {
+		actions.Insert(vpcActions...)
+		for _, attachment := range spec.VPC.Attachments {
+			if attachment.TransitGatewayID != "" {
+				actions.Insert(transitGatewayAttachmentActions...)
+			}
+		}
+	}
+
+	if spec.VPC != nil && spec.VPC.FlowLogs != nil {
+		actions.Insert(flowLogsActions...)
+		if spec.VPC.FlowLogs.Destination == api.FlowLogsDestinationS3 {
+			actions.Insert(flowLogsS3Actions...)
+		} else {
+			actions.Insert(flowLogsCloudWatchLogsActions...)
+			actions.Insert(roleActions...)
+		}
+	}
+
+	if spec.IAM.ServiceRoleARN == "" {
+		actions.Insert(roleActions...)
+	}
+	withPermissionsBoundary := spec.IAM.PermissionsBoundary != ""
+
+	for _, ng := range nodeGroups {
+		actions.Insert(nodeGroupActions...)
+		if ng.IAM == nil || ng.IAM.InstanceProfileARN == "" {
+			actions.Insert(instanceProfileActions...)
+		} else if ng.IAM.InstanceRoleARN == "" {
+			// role of a given instance profile is imported
+			actions.Insert("iam:GetInstanceProfile")
+		}
+		if ng.IAM == nil || (ng.IAM.InstanceProfileARN == "" && ng.IAM.InstanceRoleARN == "") {
+			actions.Insert(roleActions...)
+			if ng.IAM != nil && ng.IAM.PermissionsBoundary != "" {
+				withPermissionsBoundary = true
+			}
+		}
+	}
+
+	if withPermissionsBoundary {
+		actions.Insert("iam:PutRolePermissionsBoundary")
+	}
+
+	return actions.List()
+}
+
+// createsVPC checks whether a dedicated VPC is created for the cluster, which is
+// the case unless an existing VPC or its subnets are given
+func createsVPC(spec *api.ClusterConfig) bool {
+	if spec.VPC == nil {
+		return true
+	}
+	if spec.VPC.ID != "" || spec.VPC.Selector != nil {
+		return false
+	}
+	for _, id := range append(spec.PrivateSubnetIDs(), spec.PublicSubnetIDs()...) {
+		if id != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// MakePolicyDocument returns an IAM policy document that allows the given actions
+func MakePolicyDocument(actions []string) map[string]interface{} {
+	return map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Effect":   "Allow",
+				"Action":   actions,
+				"Resource": "*",
+			},
+		},
+	}
+}
+
+// MissingPermission is an action that the principal is not allowed to perform
+type MissingPermission struct {
+	Action   string
+	Decision string
+}
+
+// IsExplicitlyDenied checks whether a policy denies the action; an action that is
+// only implicitly denied may still be allowed by a policy with conditions on
+// values that are not known to the simulation, e.g. MFA or tags
+func (m *MissingPermission) IsExplicitlyDenied() bool {
+	return m.Decision == awsiam.PolicyEvaluationDecisionTypeExplicitDeny
+}
+
+func (m *MissingPermission) String() string {
+	return fmt.Sprintf("%s (%s)", m.Action, m.Decision)
+}
+
+// SimulationPrincipalARN returns the ARN of the IAM user or role that the caller ARN returned
+// by STS belongs to, as policies of role sessions cannot be simulated; an empty string is
+// returned for the root user and for federated users, as these cannot be simulated
+func SimulationPrincipalARN(provider api.ClusterProvider, callerARN string) (string, error) {
+	parsed, err := arn.Parse(callerARN)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing caller ARN %q", callerARN)
+	}
+	resourceParts := strings.Split(parsed.Resource, "/")
+
+	switch {
+	case parsed.Service == "iam" && resourceParts[0] == "user":
+		return callerARN, nil
+	case parsed.Service == "iam" && resourceParts[0] == "role":
+		return callerARN, nil
+	case parsed.Service == "sts" && resourceParts[0] == "assumed-role" && len(resourceParts) > 1:
+		// the path of the role is not part of the session ARN
+		output, err := provider.IAM().GetRole(&awsiam.GetRoleInput{RoleName: aws.String(resourceParts[1])})
+		if err != nil {
+			return "", errors.Wrapf(err, "getting role %q of the current session", resourceParts[1])
+		}
+		return *output.Role.Arn, nil
+	default:
+		return "", nil
+	}
+}
+
+// MissingPermissions simulates the policies of the principal for the given actions, with the
+// region of the provider and a secure transport as context, and returns the actions that are
+// not allowed
+func MissingPermissions(provider api.ClusterProvider, principalARN string, actions []string) ([]*MissingPermission, error) {
+	missing := []*MissingPermission{}
+
+	input := &awsiam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principalARN),
+		ActionNames:     aws.StringSlice(actions),
+		ContextEntries: []*awsiam.ContextEntry{
+			{
+				ContextKeyName:   aws.String("aws:RequestedRegion"),
+				ContextKeyType:   aws.String(awsiam.ContextKeyTypeEnumString),
+				ContextKeyValues: aws.StringSlice([]string{provider.Region()}),
+			},
+			{
+				ContextKeyName:   aws.String("aws:SecureTransport"),
+				ContextKeyType:   aws.String(awsiam.ContextKeyTypeEnumBoolean),
+				ContextKeyValues: aws.StringSlice([]string{"true"}),
+			},
+		},
+	}
+	for {
+		output, err := provider.IAM().SimulatePrincipalPolicy(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "simulating policies of %q", principalARN)
+		}
+		for _, result := range output.EvaluationResults {
+			if decision := aws.StringValue(result.EvalDecision); decision != awsiam.PolicyEvaluationDecisionTypeAllowed {
+				missing = append(missing, &MissingPermission{Action: aws.StringValue(result.EvalActionName), Decision: decision})
+			}
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			return missing, nil
+		}
+		input.Marker = output.Marker
+	}
+}
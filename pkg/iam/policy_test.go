@@ -0,0 +1,160 @@
+package iam_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("IAM policy for creating clusters", func() {
+	var (
+		provider *api.ProviderConfig
+		cfg      *api.ClusterConfig
+		ng       *api.NodeGroup
+	)
+
+	BeforeEach(func() {
+		provider = &api.ProviderConfig{}
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test"
+		ng = cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+		ng.SSH.PublicKeyPath = nil
+		Expect(api.SetNodeGroupDefaults(0, ng)).To(Succeed())
+	})
+
+	It("should require all actions for a cluster with a dedicated VPC", func() {
+		actions := RequiredActions(provider, cfg, cfg.NodeGroups)
+
+		Expect(actions).To(ContainElement("cloudformation:CreateStack"))
+		Expect(actions).To(ContainElement("eks:CreateCluster"))
+		Expect(actions).To(ContainElement("ec2:CreateVpc"))
+		Expect(actions).To(ContainElement("iam:CreateRole"))
+		Expect(actions).To(ContainElement("iam:CreateInstanceProfile"))
+		Expect(actions).To(ContainElement("autoscaling:CreateAutoScalingGroup"))
+		Expect(actions).To(ContainElement("cloudformation:GetTemplate"))
+		Expect(actions).To(ContainElement("s3:CreateBucket"))
+		Expect(actions).To(ContainElement("s3:PutObject"))
+		Expect(actions).ToNot(ContainElement("ec2:ImportKeyPair"))
+		Expect(actions).ToNot(ContainElement("iam:PutRolePermissionsBoundary"))
+	})
+
+	It("should not require VPC actions for an existing VPC", func() {
+		cfg.VPC.ID = "vpc-0123"
+		cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:role/eks-service"
+		ng.IAM.InstanceProfileARN = "arn:aws:iam::123456789012:instance-profile/nodes"
+		ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/nodes"
+
+		actions := RequiredActions(provider, cfg, cfg.NodeGroups)
+
+		Expect(actions).To(ContainElement("eks:CreateCluster"))
+		Expect(actions).To(ContainElement("iam:PassRole"))
+		Expect(actions).ToNot(ContainElement("ec2:CreateVpc"))
+		Expect(actions).ToNot(ContainElement("iam:CreateRole"))
+		Expect(actions).ToNot(ContainElement("iam:CreateInstanceProfile"))
+	})
+
+	It("should only require actions of eksctl itself when a CloudFormation role is used", func() {
+		provider.CloudFormationRoleARN = "arn:aws:iam::123456789012:role/cfn"
+		provider.CloudFormationTemplateBucket = "templates"
+		ng.SSH.Allow = api.Enabled()
+		ng.IAM.PermissionsBoundary = "arn:aws:iam::123456789012:policy/boundary"
+
+		actions := RequiredActions(provider, cfg, cfg.NodeGroups)
+
+		Expect(actions).To(ContainElement("cloudformation:CreateStack"))
+		Expect(actions).To(ContainElement("iam:PassRole"))
+		Expect(actions).To(ContainElement("s3:PutObject"))
+		Expect(actions).To(ContainElement("s3:DeleteObject"))
+		Expect(actions).ToNot(ContainElement("s3:CreateBucket"))
+		Expect(actions).To(ContainElement("ec2:ImportKeyPair"))
+		Expect(actions).ToNot(ContainElement("eks:CreateCluster"))
+		Expect(actions).ToNot(ContainElement("iam:CreateRole"))
+		Expect(actions).ToNot(ContainElement("iam:PutRolePermissionsBoundary"))
+	})
+
+	It("should require a permissions boundary to be set on generated roles", func() {
+		cfg.IAM.PermissionsBoundary = "arn:aws:iam::123456789012:policy/boundary"
+
+		Expect(RequiredActions(provider, cfg, cfg.NodeGroups)).To(ContainElement("iam:PutRolePermissionsBoundary"))
+	})
+
+	It("should make a policy document that allows the actions", func() {
+		doc := MakePolicyDocument([]string{"eks:CreateCluster"})
+
+		Expect(doc).To(HaveKeyWithValue("Version", "2012-10-17"))
+		Expect(doc["Statement"]).To(ConsistOf(map[string]interface{}{
+			"Effect":   "Allow",
+			"Action":   []string{"eks:CreateCluster"},
+			"Resource": "*",
+		}))
+	})
+})
+
+var _ = Describe("IAM policy simulation", func() {
+	var p *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+	})
+
+	It("should simulate users and roles directly", func() {
+		principal, err := SimulationPrincipalARN(p, "arn:aws:iam::123456789012:user/alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(principal).To(Equal("arn:aws:iam::123456789012:user/alice"))
+	})
+
+	It("should resolve the role of an assumed-role session", func() {
+		p.MockIAM().On("GetRole", mock.MatchedBy(func(input *awsiam.GetRoleInput) bool {
+			return *input.RoleName == "admins"
+		})).Return(&awsiam.GetRoleOutput{Role: &awsiam.Role{
+			Arn: aws.String("arn:aws:iam::123456789012:role/team/admins"),
+		}}, nil)
+
+		principal, err := SimulationPrincipalARN(p, "arn:aws:sts::123456789012:assumed-role/admins/alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(principal).To(Equal("arn:aws:iam::123456789012:role/team/admins"))
+	})
+
+	It("should not simulate the root user", func() {
+		principal, err := SimulationPrincipalARN(p, "arn:aws:iam::123456789012:root")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(principal).To(BeEmpty())
+	})
+
+	It("should return actions that are not allowed across all pages", func() {
+		p.MockIAM().On("SimulatePrincipalPolicy", mock.MatchedBy(func(input *awsiam.SimulatePrincipalPolicyInput) bool {
+			return input.Marker == nil && *input.ContextEntries[0].ContextKeyName == "aws:RequestedRegion" &&
+				*input.ContextEntries[0].ContextKeyValues[0] == p.Region()
+		})).Return(&awsiam.SimulatePolicyResponse{
+			EvaluationResults: []*awsiam.EvaluationResult{
+				{EvalActionName: aws.String("eks:CreateCluster"), EvalDecision: aws.String(awsiam.PolicyEvaluationDecisionTypeAllowed)},
+				{EvalActionName: aws.String("iam:CreateRole"), EvalDecision: aws.String(awsiam.PolicyEvaluationDecisionTypeImplicitDeny)},
+			},
+			IsTruncated: aws.Bool(true),
+			Marker:      aws.String("next"),
+		}, nil)
+		p.MockIAM().On("SimulatePrincipalPolicy", mock.MatchedBy(func(input *awsiam.SimulatePrincipalPolicyInput) bool {
+			return aws.StringValue(input.Marker) == "next"
+		})).Return(&awsiam.SimulatePolicyResponse{
+			EvaluationResults: []*awsiam.EvaluationResult{
+				{EvalActionName: aws.String("ec2:CreateVpc"), EvalDecision: aws.String(awsiam.PolicyEvaluationDecisionTypeExplicitDeny)},
+			},
+			IsTruncated: aws.Bool(false),
+		}, nil)
+
+		missing, err := MissingPermissions(p, "arn:aws:iam::123456789012:user/alice", []string{"eks:CreateCluster", "iam:CreateRole", "ec2:CreateVpc"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missing).To(HaveLen(2))
+		Expect(missing[0].String()).To(Equal("iam:CreateRole (implicitDeny)"))
+		Expect(missing[0].IsExplicitlyDenied()).To(BeFalse())
+		Expect(missing[1].String()).To(Equal("ec2:CreateVpc (explicitDeny)"))
+		Expect(missing[1].IsExplicitlyDenied()).To(BeTrue())
+	})
+})
@@ -0,0 +1,88 @@
+package lock
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap that holds the lock
+	ConfigMapName = "eksctl-lock"
+	// ConfigMapNamespace is the namespace of the ConfigMap that holds the lock
+	ConfigMapNamespace = metav1.NamespaceSystem
+
+	configMapLockKey = "lock"
+)
+
+// ConfigMapBackend stores the lock as a lease in a ConfigMap, creation
+// of the ConfigMap is atomic, so only one holder can ever succeed
+type ConfigMapBackend struct {
+	clientSet kubernetes.Interface
+}
+
+// NewConfigMapBackend creates a backend that stores the lock in kube-system
+func NewConfigMapBackend(clientSet kubernetes.Interface) *ConfigMapBackend {
+	return &ConfigMapBackend{clientSet: clientSet}
+}
+
+func (b *ConfigMapBackend) get() (*corev1.ConfigMap, *Lock, error) {
+	cm, err := b.clientSet.CoreV1().ConfigMaps(ConfigMapNamespace).Get(ConfigMapName, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "getting ConfigMap %q", ConfigMapName)
+	}
+	l, err := Decode(cm.Data[configMapLockKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	return cm, l, nil
+}
+
+// Get returns the lock stored in the ConfigMap, if any
+func (b *ConfigMapBackend) Get() (*Lock, error) {
+	_, l, err := b.get()
+	return l, err
+}
+
+// Create creates the ConfigMap, unless it exists already
+func (b *ConfigMapBackend) Create(l *Lock) error {
+	data, err := l.Encode()
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: ConfigMapNamespace,
+		},
+		Data: map[string]string{configMapLockKey: data},
+	}
+	_, err = b.clientSet.CoreV1().ConfigMaps(ConfigMapNamespace).Create(cm)
+	if apierrs.IsAlreadyExists(err) {
+		return ErrAlreadyLocked
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating ConfigMap %q", ConfigMapName)
+	}
+	return nil
+}
+
+// Delete removes the ConfigMap, if it holds the given lock
+func (b *ConfigMapBackend) Delete(l *Lock) error {
+	cm, current, err := b.get()
+	if err != nil || current == nil || current.ID != l.ID {
+		return err
+	}
+	err = b.clientSet.CoreV1().ConfigMaps(ConfigMapNamespace).Delete(ConfigMapName, &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &cm.UID},
+	})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting ConfigMap %q", ConfigMapName)
+	}
+	return nil
+}
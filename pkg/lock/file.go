@@ -0,0 +1,67 @@
+package lock
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileBackend stores the lock in a local file, it only prevents concurrent
+// operations on the same machine, and it's mostly useful for testing
+type FileBackend struct {
+	Path string
+}
+
+// NewFileBackend creates a backend that stores the lock at path
+func NewFileBackend(path string) *FileBackend {
+	return &FileBackend{Path: path}
+}
+
+// Get returns the lock stored in the file, if any
+func (b *FileBackend) Get() (*Lock, error) {
+	data, err := ioutil.ReadFile(b.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading lock file %q", b.Path)
+	}
+	return Decode(string(data))
+}
+
+// Create writes the lock to the file, unless it exists already
+func (b *FileBackend) Create(l *Lock) error {
+	data, err := l.Encode()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(b.Path), 0755); err != nil {
+		return errors.Wrapf(err, "creating directory for lock file %q", b.Path)
+	}
+	f, err := os.OpenFile(b.Path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return ErrAlreadyLocked
+	}
+	if err != nil {
+		return errors.Wrapf(err, "creating lock file %q", b.Path)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		return errors.Wrapf(err, "writing lock file %q", b.Path)
+	}
+	return nil
+}
+
+// Delete removes the file, if it holds the given lock
+func (b *FileBackend) Delete(l *Lock) error {
+	current, err := b.Get()
+	if err != nil || current == nil || current.ID != l.ID {
+		return err
+	}
+	if err := os.Remove(b.Path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "removing lock file %q", b.Path)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// DefaultTTL is how long a lock is held, unless it's released earlier,
+// it should be longer than any mutating operation takes
+const DefaultTTL = time.Hour
+
+// ErrAlreadyLocked is returned by backends when a lock cannot be created,
+// as there is one already
+var ErrAlreadyLocked = errors.New("already locked")
+
+// Lock is an advisory lock of a cluster, held for the duration of a mutating operation
+type Lock struct {
+	ID        string    `json:"id"`
+	Holder    string    `json:"holder"`
+	Operation string    `json:"operation"`
+	Acquired  time.Time `json:"acquired"`
+	Expires   time.Time `json:"expires"`
+}
+
+// Expired returns true when the lock is no longer valid
+func (l *Lock) Expired() bool {
+	return time.Now().After(l.Expires)
+}
+
+// Encode returns the JSON representation of the lock
+func (l *Lock) Encode() (string, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding lock")
+	}
+	return string(data), nil
+}
+
+// Decode parses the JSON representation of a lock
+func Decode(data string) (*Lock, error) {
+	l := &Lock{}
+	if err := json.Unmarshal([]byte(data), l); err != nil {
+		return nil, errors.Wrap(err, "decoding lock")
+	}
+	return l, nil
+}
+
+// Backend stores the lock of a cluster
+type Backend interface {
+	// Get returns the current lock, or nil when the cluster is not locked
+	Get() (*Lock, error)
+	// Create stores the lock, it returns ErrAlreadyLocked when there is a lock already
+	Create(*Lock) error
+	// Delete removes the given lock, it doesn't remove any other lock; it may return
+	// before the lock is gone, in which case Get waits for the removal to complete
+	Delete(*Lock) error
+}
+
+// LockedError is returned when a lock cannot be acquired, as it's held by someone else
+type LockedError struct {
+	Lock *Lock
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("cluster is locked by %s, who is running %q since %s (the lock expires at %s)",
+		e.Lock.Holder, e.Lock.Operation, e.Lock.Acquired.Local().Format(time.RFC3339), e.Lock.Expires.Local().Format(time.RFC3339))
+}
+
+// Locker acquires and releases locks using a backend
+type Locker struct {
+	Backend Backend
+	TTL     time.Duration
+}
+
+// Acquire takes the lock for the given operation, a lock that has expired is taken over
+func (l *Locker) Acquire(operation string) (*Lock, error) {
+	current, err := l.Backend.Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting current lock")
+	}
+	if current != nil {
+		if !current.Expired() {
+			return nil, &LockedError{Lock: current}
+		}
+		logger.Warning("lock held by %s for %q has expired, it will be taken over", current.Holder, current.Operation)
+		if err := l.Backend.Delete(current); err != nil {
+			return nil, errors.Wrap(err, "removing expired lock")
+		}
+		// the expired lock must be gone before a new one can be created
+		if current, err = l.Backend.Get(); err != nil {
+			return nil, errors.Wrap(err, "getting current lock")
+		}
+		if current != nil {
+			return nil, &LockedError{Lock: current}
+		}
+	}
+
+	lock, err := newLock(operation, l.TTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.Backend.Create(lock); err != nil {
+		if err != ErrAlreadyLocked {
+			return nil, errors.Wrap(err, "creating lock")
+		}
+		// someone else was quicker
+		current, err := l.Backend.Get()
+		if err != nil || current == nil {
+			return nil, errors.Wrap(ErrAlreadyLocked, "creating lock")
+		}
+		return nil, &LockedError{Lock: current}
+	}
+	logger.Debug("acquired lock %#v", lock)
+	return lock, nil
+}
+
+// Release removes the lock, unless it was taken over by someone else
+func (l *Locker) Release(lock *Lock) error {
+	return l.Backend.Delete(lock)
+}
+
+// ForceRelease removes any lock, regardless of who holds it; it returns the removed
+// lock, or nil when there was none
+func (l *Locker) ForceRelease() (*Lock, error) {
+	current, err := l.Backend.Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting current lock")
+	}
+	if current == nil {
+		return nil, nil
+	}
+	return current, l.Backend.Delete(current)
+}
+
+func newLock(operation string, ttl time.Duration) (*Lock, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "generating lock ID")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	return &Lock{
+		ID:        hex.EncodeToString(id),
+		Holder:    holder(),
+		Operation: operation,
+		Acquired:  now,
+		Expires:   now.Add(ttl),
+	}, nil
+}
+
+// holder identifies who holds the lock as "user@host"
+func holder() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return name + "@" + host
+}
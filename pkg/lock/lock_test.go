@@ -0,0 +1,130 @@
+package lock_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/weaveworks/eksctl/pkg/lock"
+)
+
+var _ = Describe("cluster lock", func() {
+	testLocker := func(newBackend func() Backend) {
+		var (
+			backend Backend
+			locker  *Locker
+		)
+
+		BeforeEach(func() {
+			backend = newBackend()
+			locker = &Locker{Backend: backend, TTL: time.Minute}
+		})
+
+		It("should acquire and release the lock", func() {
+			l, err := locker.Acquire("scale nodegroup")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(l.Operation).To(Equal("scale nodegroup"))
+			Expect(l.Holder).To(ContainSubstring("@"))
+			Expect(l.Expires.Sub(l.Acquired)).To(Equal(time.Minute))
+
+			current, err := backend.Get()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(current.ID).To(Equal(l.ID))
+
+			Expect(locker.Release(l)).To(Succeed())
+			current, err = backend.Get()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(current).To(BeNil())
+		})
+
+		It("should not acquire a lock that is held", func() {
+			l, err := locker.Acquire("create nodegroup")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = locker.Acquire("scale nodegroup")
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&LockedError{}))
+			Expect(err.Error()).To(ContainSubstring(`running "create nodegroup"`))
+
+			// releasing a lock that is not held doesn't remove the current one
+			Expect(locker.Release(&Lock{ID: "other"})).To(Succeed())
+			current, err := backend.Get()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(current.ID).To(Equal(l.ID))
+		})
+
+		It("should take over an expired lock", func() {
+			expired := &Lock{
+				ID:        "expired",
+				Holder:    "someone@somewhere",
+				Operation: "delete nodegroup",
+				Acquired:  time.Now().Add(-2 * time.Hour),
+				Expires:   time.Now().Add(-time.Hour),
+			}
+			Expect(backend.Create(expired)).To(Succeed())
+			Expect(backend.Create(&Lock{ID: "another"})).To(Equal(ErrAlreadyLocked))
+
+			l, err := locker.Acquire("update cluster")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(l.ID).ToNot(Equal("expired"))
+		})
+
+		It("should force-release a lock held by someone else", func() {
+			l, err := locker.Acquire("update cluster")
+			Expect(err).ToNot(HaveOccurred())
+
+			released, err := locker.ForceRelease()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(released.ID).To(Equal(l.ID))
+
+			released, err = locker.ForceRelease()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(released).To(BeNil())
+		})
+	}
+
+	Context("with file backend", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "eksctl-lock-test")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		testLocker(func() Backend {
+			return NewFileBackend(filepath.Join(dir, "locks", "test-cluster.json"))
+		})
+	})
+
+	Context("with ConfigMap backend", func() {
+		var clientSet *fake.Clientset
+
+		testLocker(func() Backend {
+			clientSet = fake.NewSimpleClientset()
+			return NewConfigMapBackend(clientSet)
+		})
+
+		It("should store the lock in kube-system", func() {
+			locker := &Locker{Backend: NewConfigMapBackend(clientSet)}
+			l, err := locker.Acquire("delete cluster")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(l.Expires.Sub(l.Acquired)).To(Equal(DefaultTTL))
+
+			cm, err := clientSet.CoreV1().ConfigMaps("kube-system").Get(ConfigMapName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cm.Data["lock"]).To(ContainSubstring(l.ID))
+		})
+	})
+})
@@ -7,7 +7,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -23,6 +26,9 @@ type MockProvider struct {
 	sts        *mocks.STSAPI
 	iam        *mocks.IAMAPI
 	cloudtrail *mocks.CloudTrailAPI
+	elb        *mocks.ELBAPI
+	elbv2      *mocks.ELBV2API
+	s3         *mocks.S3API
 }
 
 // NewMockProvider returns a new MockProvider
@@ -34,6 +40,9 @@ func NewMockProvider() *MockProvider {
 		sts:        &mocks.STSAPI{},
 		iam:        &mocks.IAMAPI{},
 		cloudtrail: &mocks.CloudTrailAPI{},
+		elb:        &mocks.ELBAPI{},
+		elbv2:      &mocks.ELBV2API{},
+		s3:         &mocks.S3API{},
 	}
 }
 
@@ -42,6 +51,7 @@ var ProviderConfig = &api.ProviderConfig{
 	Region:      api.DefaultRegion,
 	Profile:     "default",
 	WaitTimeout: 1200000000000,
+	StackEvents: api.StackEventsNone,
 }
 
 // CloudFormation returns a representation of the CloudFormation API
@@ -87,6 +97,24 @@ func (m MockProvider) MockCloudTrail() *mocks.CloudTrailAPI {
 	return m.CloudTrail().(*mocks.CloudTrailAPI)
 }
 
+// ELB returns a representation of the ELB API
+func (m MockProvider) ELB() elbiface.ELBAPI { return m.elb }
+
+// MockELB returns a mocked ELB API
+func (m MockProvider) MockELB() *mocks.ELBAPI { return m.ELB().(*mocks.ELBAPI) }
+
+// ELBV2 returns a representation of the ELBV2 API
+func (m MockProvider) ELBV2() elbv2iface.ELBV2API { return m.elbv2 }
+
+// MockELBV2 returns a mocked ELBV2 API
+func (m MockProvider) MockELBV2() *mocks.ELBV2API { return m.ELBV2().(*mocks.ELBV2API) }
+
+// S3 returns a representation of the S3 API
+func (m MockProvider) S3() s3iface.S3API { return m.s3 }
+
+// MockS3 returns a mocked S3 API
+func (m MockProvider) MockS3() *mocks.S3API { return m.S3().(*mocks.S3API) }
+
 // Profile returns current profile setting
 func (m MockProvider) Profile() string { return ProviderConfig.Profile }
 
@@ -95,3 +123,11 @@ func (m MockProvider) Region() string { return ProviderConfig.Region }
 
 // WaitTimeout returns current timeout setting
 func (m MockProvider) WaitTimeout() time.Duration { return ProviderConfig.WaitTimeout }
+
+// StackEventsFormat returns current stack events format setting
+func (m MockProvider) StackEventsFormat() string { return ProviderConfig.StackEvents }
+
+// CloudFormationTemplateBucket returns current template bucket setting
+func (m MockProvider) CloudFormationTemplateBucket() string {
+	return ProviderConfig.CloudFormationTemplateBucket
+}
@@ -1,11 +1,15 @@
 package kubeconfig
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/weaveworks/eksctl/pkg/utils/file"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
@@ -22,6 +26,8 @@ const (
 	AWSIAMAuthenticator = "aws-iam-authenticator"
 	// HeptioAuthenticatorAWS defines the old name of AWS IAM authenticator
 	HeptioAuthenticatorAWS = "heptio-authenticator-aws"
+	// EksctlAuthenticator defines the name of eksctl command, which can generate tokens itself
+	EksctlAuthenticator = "eksctl"
 )
 
 // AuthenticatorCommands returns all of authenticator commands
@@ -66,17 +72,35 @@ func New(spec *api.ClusterConfig, username, certificateAuthorityPath string) (*c
 	return c, clusterName, contextName
 }
 
-// AppendAuthenticator appends the AWS IAM  authenticator, and
-// if profile is non-empty string it sets AWS_PROFILE environment
+// IsEksctlAuthenticator returns true when command refers to eksctl itself,
+// either by name or by path
+func IsEksctlAuthenticator(command string) bool {
+	return strings.TrimSuffix(filepath.Base(command), ".exe") == EksctlAuthenticator
+}
+
+// AppendAuthenticator appends the AWS IAM  authenticator, or eksctl
+// itself, as given by authenticator.Command; the role to assume and
+// extra environment variables are passed on to the command, and if
+// profile is non-empty string it sets AWS_PROFILE environment
 // variable also
-func AppendAuthenticator(c *clientcmdapi.Config, spec *api.ClusterConfig, command, profile string) {
+func AppendAuthenticator(c *clientcmdapi.Config, spec *api.ClusterConfig, authenticator *api.KubeconfigAuthenticator, profile string) {
+	args := []string{"token", "-i", spec.Metadata.Name}
+	roleFlag := "-r"
+	if IsEksctlAuthenticator(authenticator.Command) {
+		args = []string{"get", "token", "--cluster", spec.Metadata.Name, "--region", spec.Metadata.Region}
+		roleFlag = "--role-arn"
+	}
+	if authenticator.RoleARN != "" {
+		args = append(args, roleFlag, authenticator.RoleARN)
+	}
+
 	execConfig := &clientcmdapi.ExecConfig{
 		APIVersion: "client.authentication.k8s.io/v1alpha1",
-		Command:    command,
-		Args:       []string{"token", "-i", spec.Metadata.Name},
+		Command:    authenticator.Command,
+		Args:       args,
 	}
 
-	if profile != "" {
+	if _, ok := authenticator.Env["AWS_PROFILE"]; profile != "" && !ok {
 		execConfig.Env = []clientcmdapi.ExecEnvVar{
 			clientcmdapi.ExecEnvVar{
 				Name:  "AWS_PROFILE",
@@ -85,25 +109,87 @@ func AppendAuthenticator(c *clientcmdapi.Config, spec *api.ClusterConfig, comman
 		}
 	}
 
+	names := []string{}
+	for name := range authenticator.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		execConfig.Env = append(execConfig.Env, clientcmdapi.ExecEnvVar{Name: name, Value: authenticator.Env[name]})
+	}
+
 	c.AuthInfos[c.CurrentContext] = &clientcmdapi.AuthInfo{
 		Exec: execConfig,
 	}
 }
 
+// DefaultContextNameTemplate is the template of context names used by New
+const DefaultContextNameTemplate = "{{.Username}}@{{.ClusterName}}.{{.Region}}.eksctl.io"
+
+// ContextNameData holds the fields that can be used in context name templates
+type ContextNameData struct {
+	Username    string
+	ClusterName string
+	Region      string
+}
+
+// ContextName renders a context name template, e.g. "{{.ClusterName}}-{{.Region}}"
+func ContextName(nameTemplate string, data ContextNameData) (string, error) {
+	t, err := template.New("context").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing context name template %q", nameTemplate)
+	}
+	name := &bytes.Buffer{}
+	if err := t.Execute(name, data); err != nil {
+		return "", errors.Wrapf(err, "rendering context name template %q", nameTemplate)
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("context name template %q renders an empty name", nameTemplate)
+	}
+	return name.String(), nil
+}
+
+// RenameContext renames the current context, along with its user
+func RenameContext(c *clientcmdapi.Config, name string) {
+	if name == c.CurrentContext {
+		return
+	}
+	c.Contexts[name] = c.Contexts[c.CurrentContext]
+	c.Contexts[name].AuthInfo = name
+	c.AuthInfos[name] = c.AuthInfos[c.CurrentContext]
+	delete(c.Contexts, c.CurrentContext)
+	delete(c.AuthInfos, c.CurrentContext)
+	c.CurrentContext = name
+}
+
 // Write will write Kubernetes client configuration to a file.
 // If path isn't specified then the path will be determined by client-go.
 // If file pointed to by path doesn't exist it will be created.
 // If the file already exists then the configuration will be merged with the existing file.
 func Write(path string, newConfig clientcmdapi.Config, setContext bool) (string, error) {
+	filename, _, err := write(path, newConfig, setContext, nil, nil)
+	return filename, err
+}
+
+// WriteAndPrune merges configuration for a set of clusters into a file, like Write does,
+// and removes the clusters that eksctl has written for any of the given regions, but that
+// are not among the existing clusters; it returns the clusters that were removed
+func WriteAndPrune(path string, newConfig clientcmdapi.Config, setContext bool, regions []string, existing []*api.ClusterMeta) (string, []*api.ClusterMeta, error) {
+	return write(path, newConfig, setContext, regions, existing)
+}
+
+func write(path string, newConfig clientcmdapi.Config, setContext bool, pruneRegions []string, existing []*api.ClusterMeta) (string, []*api.ClusterMeta, error) {
 	configAccess := getConfigAccess(path)
 
 	config, err := configAccess.GetStartingConfig()
 	if err != nil {
-		return "", errors.Wrapf(err, "enable to read existing kubeconfig file %q", path)
+		return "", nil, errors.Wrapf(err, "enable to read existing kubeconfig file %q", path)
 	}
 
+	pruned := pruneClusters(config, pruneRegions, existing)
+
 	logger.Debug("merging kubeconfig files")
-	merged := merge(config, &newConfig)
+	merged := Merge(config, &newConfig)
 
 	if setContext && newConfig.CurrentContext != "" {
 		logger.Debug("setting current-context to %s", newConfig.CurrentContext)
@@ -111,10 +197,42 @@ func Write(path string, newConfig clientcmdapi.Config, setContext bool) (string,
 	}
 
 	if err := clientcmd.ModifyConfig(configAccess, *merged, true); err != nil {
-		return "", nil
+		return "", nil, nil
 	}
 
-	return configAccess.GetDefaultFilename(), nil
+	return configAccess.GetDefaultFilename(), pruned, nil
+}
+
+// pruneClusters removes clusters that were written by eksctl for any of the regions,
+// unless they exist; clusters are identified by names returned by ClusterMeta.String
+func pruneClusters(config *clientcmdapi.Config, regions []string, existing []*api.ClusterMeta) []*api.ClusterMeta {
+	isExisting := map[string]bool{}
+	for _, cl := range existing {
+		isExisting[cl.String()] = true
+	}
+	isPruned := map[string]bool{}
+	for _, region := range regions {
+		isPruned[region] = true
+	}
+
+	pruned := []*api.ClusterMeta{}
+	for clusterName := range config.Clusters {
+		if !strings.HasSuffix(clusterName, ".eksctl.io") || isExisting[clusterName] {
+			continue
+		}
+		nameAndRegion := strings.TrimSuffix(clusterName, ".eksctl.io")
+		i := strings.LastIndex(nameAndRegion, ".")
+		if i < 0 || !isPruned[nameAndRegion[i+1:]] {
+			continue
+		}
+		pruned = append(pruned, &api.ClusterMeta{Name: nameAndRegion[:i], Region: nameAndRegion[i+1:]})
+	}
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i].String() < pruned[j].String() })
+
+	for _, cl := range pruned {
+		deleteClusterInfo(config, cl)
+	}
+	return pruned
 }
 
 func getConfigAccess(explicitPath string) clientcmd.ConfigAccess {
@@ -125,7 +243,10 @@ func getConfigAccess(explicitPath string) clientcmd.ConfigAccess {
 
 	return interface{}(pathOptions).(clientcmd.ConfigAccess)
 }
-func merge(existing *clientcmdapi.Config, tomerge *clientcmdapi.Config) *clientcmdapi.Config {
+
+// Merge adds clusters, users and contexts of tomerge to existing, replacing
+// any that have the same names
+func Merge(existing *clientcmdapi.Config, tomerge *clientcmdapi.Config) *clientcmdapi.Config {
 	for k, v := range tomerge.Clusters {
 		existing.Clusters[k] = v
 	}
@@ -225,22 +346,23 @@ func deleteClusterInfo(existing *clientcmdapi.Config, cl *api.ClusterMeta) bool
 				delete(existing.AuthInfos, name)
 				logger.Debug("removed user for %q from kubeconfig", name)
 			}
-			currentContextName = name
-			break
+			if existing.CurrentContext == name {
+				currentContextName = name
+			}
 		}
 	}
 
-	if existing.CurrentContext == currentContextName {
+	if currentContextName != "" {
 		existing.CurrentContext = ""
-		logger.Debug("reset current-context in kubeconfig", currentContextName)
+		logger.Debug("reset current-context %q in kubeconfig", currentContextName)
 		isChanged = true
 	}
 
 	if parts := strings.Split(existing.CurrentContext, "@"); len(parts) == 2 {
 		if strings.HasSuffix(parts[1], "eksctl.io") {
 			if _, ok := existing.Contexts[existing.CurrentContext]; !ok {
+				logger.Debug("reset stale current-context %q in kubeconfig", existing.CurrentContext)
 				existing.CurrentContext = ""
-				logger.Debug("reset stale current-context in kubeconfig", currentContextName)
 				isChanged = true
 			}
 		}
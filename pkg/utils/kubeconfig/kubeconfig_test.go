@@ -282,6 +282,31 @@ var _ = Describe("Kubeconfig", func() {
 			Expect(configFileAsBytes).To(MatchYAML(oneClusterAsBytes), "Failed to delete cluster from config")
 		})
 
+		It("prunes clusters that no longer exist in the given regions", func() {
+			existing := []*eksctlapi.ClusterMeta{{Name: "cluster-two", Region: "us-west-2"}}
+			_, pruned, err := kubeconfig.WriteAndPrune(configFile.Name(), *api.NewConfig(), false, []string{"us-west-2"}, existing)
+			Expect(err).To(BeNil())
+			Expect(pruned).To(Equal([]*eksctlapi.ClusterMeta{{Name: "cluster-one", Region: "us-west-2"}}))
+
+			readConfig, err := clientcmd.LoadFromFile(configFile.Name())
+			Expect(err).To(BeNil())
+			Expect(readConfig.Clusters).To(HaveLen(1))
+			Expect(readConfig.Clusters).To(HaveKey("cluster-two.us-west-2.eksctl.io"))
+			Expect(readConfig.Contexts).To(HaveLen(1))
+			Expect(readConfig.AuthInfos).To(HaveLen(1))
+			Expect(readConfig.CurrentContext).To(BeEmpty())
+		})
+
+		It("does not prune clusters in other regions", func() {
+			_, pruned, err := kubeconfig.WriteAndPrune(configFile.Name(), *api.NewConfig(), false, []string{"eu-north-1"}, nil)
+			Expect(err).To(BeNil())
+			Expect(pruned).To(BeEmpty())
+
+			configFileAsBytes, err := ioutil.ReadFile(configFile.Name())
+			Expect(err).To(BeNil())
+			Expect(configFileAsBytes).To(MatchYAML(twoClustersAsBytes), "Should not change")
+		})
+
 		It("not change the kubeconfig if the kubeconfig does not include the cluster", func() {
 			nonExistentClusterConfig := GetClusterConfig("not-a-cluster")
 			kubeconfig.MaybeDeleteConfig(nonExistentClusterConfig.Metadata)
@@ -291,4 +316,110 @@ var _ = Describe("Kubeconfig", func() {
 			Expect(configFileAsBytes).To(MatchYAML(twoClustersAsBytes), "Should not change")
 		})
 	})
+
+	Context("AppendAuthenticator", func() {
+		var (
+			cfg          *eksctlapi.ClusterConfig
+			clientConfig *api.Config
+		)
+
+		BeforeEach(func() {
+			cfg = eksctlapi.NewClusterConfig()
+			cfg.Metadata.Name = "cluster-one"
+			cfg.Metadata.Region = "eu-north-1"
+			cfg.Status = &eksctlapi.ClusterStatus{Endpoint: "https://127.0.0.1:443"}
+			clientConfig, _, _ = kubeconfig.New(cfg, "test-user", "")
+		})
+
+		It("uses the arguments of aws-iam-authenticator", func() {
+			kubeconfig.AppendAuthenticator(clientConfig, cfg, &eksctlapi.KubeconfigAuthenticator{Command: kubeconfig.AWSIAMAuthenticator}, "")
+
+			exec := clientConfig.AuthInfos[clientConfig.CurrentContext].Exec
+			Expect(exec.Command).To(Equal(kubeconfig.AWSIAMAuthenticator))
+			Expect(exec.Args).To(Equal([]string{"token", "-i", "cluster-one"}))
+			Expect(exec.Env).To(BeEmpty())
+		})
+
+		It("uses the arguments of 'eksctl get token'", func() {
+			kubeconfig.AppendAuthenticator(clientConfig, cfg, &eksctlapi.KubeconfigAuthenticator{Command: kubeconfig.EksctlAuthenticator}, "")
+
+			exec := clientConfig.AuthInfos[clientConfig.CurrentContext].Exec
+			Expect(exec.Command).To(Equal(kubeconfig.EksctlAuthenticator))
+			Expect(exec.Args).To(Equal([]string{"get", "token", "--cluster", "cluster-one", "--region", "eu-north-1"}))
+			Expect(exec.Env).To(BeEmpty())
+		})
+
+		It("uses 'eksctl get token' when eksctl is referred to by path", func() {
+			kubeconfig.AppendAuthenticator(clientConfig, cfg, &eksctlapi.KubeconfigAuthenticator{Command: "/usr/local/bin/eksctl"}, "test-profile")
+
+			exec := clientConfig.AuthInfos[clientConfig.CurrentContext].Exec
+			Expect(exec.Command).To(Equal("/usr/local/bin/eksctl"))
+			Expect(exec.Args).To(Equal([]string{"get", "token", "--cluster", "cluster-one", "--region", "eu-north-1"}))
+			Expect(exec.Env).To(Equal([]api.ExecEnvVar{{Name: "AWS_PROFILE", Value: "test-profile"}}))
+		})
+
+		It("passes the role and extra environment variables to aws-iam-authenticator", func() {
+			kubeconfig.AppendAuthenticator(clientConfig, cfg, &eksctlapi.KubeconfigAuthenticator{
+				Command: kubeconfig.AWSIAMAuthenticator,
+				RoleARN: "arn:aws:iam::123456789012:role/admin",
+				Env:     map[string]string{"B": "2", "A": "1"},
+			}, "test-profile")
+
+			exec := clientConfig.AuthInfos[clientConfig.CurrentContext].Exec
+			Expect(exec.Args).To(Equal([]string{"token", "-i", "cluster-one", "-r", "arn:aws:iam::123456789012:role/admin"}))
+			Expect(exec.Env).To(Equal([]api.ExecEnvVar{
+				{Name: "AWS_PROFILE", Value: "test-profile"},
+				{Name: "A", Value: "1"},
+				{Name: "B", Value: "2"},
+			}))
+		})
+
+		It("passes the role to eksctl, and lets AWS_PROFILE be overridden", func() {
+			kubeconfig.AppendAuthenticator(clientConfig, cfg, &eksctlapi.KubeconfigAuthenticator{
+				Command: kubeconfig.EksctlAuthenticator,
+				RoleARN: "arn:aws:iam::123456789012:role/admin",
+				Env:     map[string]string{"AWS_PROFILE": "admin"},
+			}, "test-profile")
+
+			exec := clientConfig.AuthInfos[clientConfig.CurrentContext].Exec
+			Expect(exec.Args).To(Equal([]string{"get", "token", "--cluster", "cluster-one", "--region", "eu-north-1", "--role-arn", "arn:aws:iam::123456789012:role/admin"}))
+			Expect(exec.Env).To(Equal([]api.ExecEnvVar{{Name: "AWS_PROFILE", Value: "admin"}}))
+		})
+	})
+
+	Context("context names", func() {
+		It("renders the default template like New names contexts", func() {
+			cfg := eksctlapi.NewClusterConfig()
+			cfg.Metadata.Name = "cluster-one"
+			cfg.Metadata.Region = "eu-north-1"
+			cfg.Status = &eksctlapi.ClusterStatus{}
+			_, _, contextName := kubeconfig.New(cfg, "admin", "")
+
+			name, err := kubeconfig.ContextName(kubeconfig.DefaultContextNameTemplate, kubeconfig.ContextNameData{Username: "admin", ClusterName: "cluster-one", Region: "eu-north-1"})
+			Expect(err).To(BeNil())
+			Expect(name).To(Equal(contextName))
+		})
+
+		It("fails on unknown fields", func() {
+			_, err := kubeconfig.ContextName("{{.Account}}-{{.ClusterName}}", kubeconfig.ContextNameData{ClusterName: "cluster-one"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("renames the current context along with its user", func() {
+			cfg := eksctlapi.NewClusterConfig()
+			cfg.Metadata.Name = "cluster-one"
+			cfg.Metadata.Region = "eu-north-1"
+			cfg.Status = &eksctlapi.ClusterStatus{}
+			clientConfig, clusterName, _ := kubeconfig.New(cfg, "admin", "")
+
+			kubeconfig.RenameContext(clientConfig, "cluster-one-eu-north-1")
+
+			Expect(clientConfig.CurrentContext).To(Equal("cluster-one-eu-north-1"))
+			Expect(clientConfig.Contexts).To(HaveLen(1))
+			Expect(clientConfig.Contexts["cluster-one-eu-north-1"].Cluster).To(Equal(clusterName))
+			Expect(clientConfig.Contexts["cluster-one-eu-north-1"].AuthInfo).To(Equal("cluster-one-eu-north-1"))
+			Expect(clientConfig.AuthInfos).To(HaveLen(1))
+			Expect(clientConfig.AuthInfos).To(HaveKey("cluster-one-eu-north-1"))
+		})
+	})
 })
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -56,9 +57,7 @@ func CheckAllCommands(kubeconfigPath string, isContextSet bool, contextName stri
 		return err
 	}
 
-	if err := checkAuthenticator(); err != nil {
-		return err
-	}
+	checkAuthenticator()
 
 	if kubeconfigPath != "" {
 		ktl := &kubectl.LocalClient{
@@ -95,21 +94,21 @@ func CheckAllCommands(kubeconfigPath string, isContextSet bool, contextName stri
 }
 
 // checkAuthenticator checks if either of authenticator commands is in the path,
-// it returns an error when neither are found
-func checkAuthenticator() error {
+// when neither are found eksctl will be used to generate tokens
+func checkAuthenticator() {
 	for _, cmd := range kubeconfig.AuthenticatorCommands() {
 		path, err := exec.LookPath(cmd)
 		if err == nil {
 			// command was found
 			logger.Debug("%s: %q", cmd, path)
-			return nil
+			return
 		}
 	}
-	return fmt.Errorf("neither aws-iam-authenticator nor heptio-authenticator-aws are installed")
+	logger.Debug("neither aws-iam-authenticator nor heptio-authenticator-aws are installed, %s will be used as authenticator", DetectAuthenticator())
 }
 
-// DetectAuthenticator finds the authenticator command, it defaults to legacy
-// command when neither are found
+// DetectAuthenticator finds the authenticator command, when neither are found
+// it falls back to eksctl, which is referred to by path unless it's in the path
 func DetectAuthenticator() string {
 	for _, bin := range kubeconfig.AuthenticatorCommands() {
 		_, err := exec.LookPath(bin)
@@ -117,5 +116,11 @@ func DetectAuthenticator() string {
 			return bin
 		}
 	}
-	return kubeconfig.AWSIAMAuthenticator
+	if _, err := exec.LookPath(kubeconfig.EksctlAuthenticator); err == nil {
+		return kubeconfig.EksctlAuthenticator
+	}
+	if path, err := os.Executable(); err == nil && kubeconfig.IsEksctlAuthenticator(path) {
+		return path
+	}
+	return kubeconfig.EksctlAuthenticator
 }
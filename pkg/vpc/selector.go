@@ -0,0 +1,210 @@
+package vpc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ImportFromSelector discovers the VPC and subnets that match tag filters
+// given in spec.VPC.Selector, it classifies each of the subnets as public
+// or private based on whether its route table has a route to an internet
+// gateway, and then imports one subnet per availability zone for each of
+// the topologies
+func ImportFromSelector(provider api.ClusterProvider, spec *api.ClusterConfig) error {
+	selector := spec.VPC.Selector
+	if selector == nil || len(selector.Tags) == 0 {
+		return fmt.Errorf("vpc.selector.tags must be set")
+	}
+
+	spec.VPC.CIDR = nil // ensure to reset the CIDR
+
+	vpcID, err := findVPCByTags(provider, selector.Tags)
+	if err != nil {
+		return err
+	}
+	logger.Info("using VPC %q found by selector %s", vpcID, fmtTags(selector.Tags))
+
+	if err := Import(provider, spec, vpcID); err != nil {
+		return errors.Wrapf(err, "importing VPC %q", vpcID)
+	}
+
+	subnets, err := findSubnetsByTags(provider, vpcID, selector.SubnetTags)
+	if err != nil {
+		return err
+	}
+	if len(subnets) == 0 {
+		return fmt.Errorf("no subnets found in VPC %q matching selector %s", vpcID, fmtTags(selector.SubnetTags))
+	}
+
+	routeTables, err := describeRouteTables(provider, vpcID)
+	if err != nil {
+		return err
+	}
+
+	subnetsByTopology := map[api.SubnetTopology][]*ec2.Subnet{
+		api.SubnetTopologyPrivate: {},
+		api.SubnetTopologyPublic:  {},
+	}
+	for _, subnet := range subnets {
+		t := topologyOf(subnet, routeTables)
+		logger.Debug("subnet %q in %q is %s", *subnet.SubnetId, *subnet.AvailabilityZone, t)
+		subnetsByTopology[t] = append(subnetsByTopology[t], subnet)
+	}
+
+	for t, subnets := range subnetsByTopology {
+		if err := ImportSubnets(provider, spec, t, onePerAvailabilityZone(t, subnets)); err != nil {
+			return err
+		}
+	}
+
+	logger.Debug("subnets = %#v", spec.VPC.Subnets)
+	if zones := len(spec.AvailabilityZones); zones < api.MinRequiredSubnets {
+		return fmt.Errorf("subnets of VPC %q matching the selector span only %d availability zone(s), at least %d are required", vpcID, zones, api.MinRequiredSubnets)
+	}
+	if err := spec.HasSufficientSubnets(); err != nil {
+		return errors.Wrapf(err, "using VPC %q found by selector", vpcID)
+	}
+	return nil
+}
+
+func tagFilters(tags map[string]string) []*ec2.Filter {
+	keys := []string{}
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	filters := []*ec2.Filter{}
+	for _, k := range keys {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + k),
+			Values: aws.StringSlice([]string{tags[k]}),
+		})
+	}
+	return filters
+}
+
+func fmtTags(tags map[string]string) string {
+	pairs := []string{}
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return "[" + strings.Join(pairs, ",") + "]"
+}
+
+func findVPCByTags(provider api.ClusterProvider, tags map[string]string) (string, error) {
+	input := &ec2.DescribeVpcsInput{
+		Filters: tagFilters(tags),
+	}
+	output, err := provider.EC2().DescribeVpcs(input)
+	if err != nil {
+		return "", errors.Wrapf(err, "describing VPCs matching selector %s", fmtTags(tags))
+	}
+	switch len(output.Vpcs) {
+	case 0:
+		return "", fmt.Errorf("no VPC found matching selector %s", fmtTags(tags))
+	case 1:
+		return *output.Vpcs[0].VpcId, nil
+	default:
+		ids := []string{}
+		for _, vpc := range output.Vpcs {
+			ids = append(ids, *vpc.VpcId)
+		}
+		return "", fmt.Errorf("selector %s matches more than one VPC (%s), it must match exactly one", fmtTags(tags), strings.Join(ids, ", "))
+	}
+}
+
+func findSubnetsByTags(provider api.ClusterProvider, vpcID string, tags map[string]string) ([]*ec2.Subnet, error) {
+	input := &ec2.DescribeSubnetsInput{
+		Filters: append([]*ec2.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: aws.StringSlice([]string{vpcID}),
+		}}, tagFilters(tags)...),
+	}
+	output, err := provider.EC2().DescribeSubnets(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing subnets of VPC %q", vpcID)
+	}
+	return output.Subnets, nil
+}
+
+func describeRouteTables(provider api.ClusterProvider, vpcID string) ([]*ec2.RouteTable, error) {
+	input := &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: aws.StringSlice([]string{vpcID}),
+		}},
+	}
+	routeTables := []*ec2.RouteTable{}
+	err := provider.EC2().DescribeRouteTablesPages(input, func(p *ec2.DescribeRouteTablesOutput, _ bool) bool {
+		routeTables = append(routeTables, p.RouteTables...)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing route tables of VPC %q", vpcID)
+	}
+	return routeTables, nil
+}
+
+// routeTableOf returns the route table explicitly associated with the
+// subnet, or the main route table of the VPC otherwise
+func routeTableOf(subnet *ec2.Subnet, routeTables []*ec2.RouteTable) *ec2.RouteTable {
+	var main *ec2.RouteTable
+	for _, rt := range routeTables {
+		for _, a := range rt.Associations {
+			if a.SubnetId != nil && *a.SubnetId == *subnet.SubnetId {
+				return rt
+			}
+			if a.Main != nil && *a.Main {
+				main = rt
+			}
+		}
+	}
+	return main
+}
+
+// topologyOf a subnet is public when its route table has a route
+// to an internet gateway, otherwise it's private
+func topologyOf(subnet *ec2.Subnet, routeTables []*ec2.RouteTable) api.SubnetTopology {
+	rt := routeTableOf(subnet, routeTables)
+	if rt == nil {
+		return api.SubnetTopologyPrivate
+	}
+	for _, r := range rt.Routes {
+		if r.GatewayId != nil && strings.HasPrefix(*r.GatewayId, "igw-") {
+			return api.SubnetTopologyPublic
+		}
+	}
+	return api.SubnetTopologyPrivate
+}
+
+// onePerAvailabilityZone picks one subnet in each of the availability
+// zones, as only one subnet per zone can be used for each topology;
+// subnets are sorted by ID, so that the result is deterministic
+func onePerAvailabilityZone(topology api.SubnetTopology, subnets []*ec2.Subnet) []*ec2.Subnet {
+	sort.Slice(subnets, func(i, j int) bool {
+		return *subnets[i].SubnetId < *subnets[j].SubnetId
+	})
+	selected := map[string]*ec2.Subnet{}
+	result := []*ec2.Subnet{}
+	for _, subnet := range subnets {
+		az := *subnet.AvailabilityZone
+		if s, ok := selected[az]; ok {
+			logger.Warning("skipping %s subnet %q, as %q was already selected in %q", topology, *subnet.SubnetId, *s.SubnetId, az)
+			continue
+		}
+		selected[az] = subnet
+		result = append(result, subnet)
+	}
+	return result
+}
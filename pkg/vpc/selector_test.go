@@ -0,0 +1,142 @@
+package vpc_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	. "github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+func newSubnet(id, az, cidr string) *ec2.Subnet {
+	return &ec2.Subnet{
+		SubnetId:         aws.String(id),
+		AvailabilityZone: aws.String(az),
+		CidrBlock:        aws.String(cidr),
+		VpcId:            aws.String("vpc-1"),
+	}
+}
+
+var _ = Describe("VPC selector", func() {
+	var (
+		p    *mockprovider.MockProvider
+		cfg  *api.ClusterConfig
+		vpcs []*ec2.Vpc
+		err  error
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg = api.NewClusterConfig()
+		cfg.VPC.Selector = &api.VPCSelector{
+			Tags: map[string]string{"environment": "test"},
+		}
+
+		vpcs = []*ec2.Vpc{{
+			VpcId:     aws.String("vpc-1"),
+			CidrBlock: aws.String("10.0.0.0/16"),
+		}}
+
+		p.MockEC2().On("DescribeVpcs", mock.MatchedBy(func(input *ec2.DescribeVpcsInput) bool {
+			return len(input.Filters) == 1 &&
+				*input.Filters[0].Name == "tag:environment" &&
+				*input.Filters[0].Values[0] == "test"
+		})).Return(func(_ *ec2.DescribeVpcsInput) *ec2.DescribeVpcsOutput {
+			return &ec2.DescribeVpcsOutput{Vpcs: vpcs}
+		}, nil)
+
+		p.MockEC2().On("DescribeVpcs", mock.MatchedBy(func(input *ec2.DescribeVpcsInput) bool {
+			return len(input.VpcIds) == 1 && *input.VpcIds[0] == "vpc-1"
+		})).Return(&ec2.DescribeVpcsOutput{Vpcs: vpcs[:1]}, nil)
+
+		p.MockEC2().On("DescribeSubnets", mock.MatchedBy(func(input *ec2.DescribeSubnetsInput) bool {
+			return *input.Filters[0].Name == "vpc-id" && *input.Filters[0].Values[0] == "vpc-1"
+		})).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{
+				newSubnet("subnet-pub-a", "us-west-2a", "10.0.0.0/20"),
+				newSubnet("subnet-pub-b", "us-west-2b", "10.0.16.0/20"),
+				newSubnet("subnet-priv-a2", "us-west-2a", "10.0.96.0/20"),
+				newSubnet("subnet-priv-a1", "us-west-2a", "10.0.64.0/20"),
+				newSubnet("subnet-priv-b", "us-west-2b", "10.0.80.0/20"),
+			},
+		}, nil)
+
+		p.MockEC2().On("DescribeRouteTablesPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(*ec2.DescribeRouteTablesOutput, bool) bool)
+			fn(&ec2.DescribeRouteTablesOutput{
+				RouteTables: []*ec2.RouteTable{
+					{
+						RouteTableId: aws.String("rtb-main"),
+						Associations: []*ec2.RouteTableAssociation{
+							{Main: aws.Bool(true)},
+						},
+						Routes: []*ec2.Route{
+							{GatewayId: aws.String("local")},
+							{NatGatewayId: aws.String("nat-1")},
+						},
+					},
+					{
+						RouteTableId: aws.String("rtb-public"),
+						Associations: []*ec2.RouteTableAssociation{
+							{SubnetId: aws.String("subnet-pub-a")},
+							{SubnetId: aws.String("subnet-pub-b")},
+						},
+						Routes: []*ec2.Route{
+							{GatewayId: aws.String("local")},
+							{GatewayId: aws.String("igw-1")},
+						},
+					},
+				},
+			}, true)
+		}).Return(nil)
+	})
+
+	JustBeforeEach(func() {
+		err = ImportFromSelector(p, cfg)
+	})
+
+	Context("with one matching VPC", func() {
+		It("should import the VPC", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.VPC.ID).To(Equal("vpc-1"))
+			Expect(cfg.VPC.CIDR.String()).To(Equal("10.0.0.0/16"))
+		})
+
+		It("should classify subnets by route table", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.PublicSubnetIDs()).To(ConsistOf("subnet-pub-a", "subnet-pub-b"))
+			Expect(cfg.PrivateSubnetIDs()).To(ConsistOf("subnet-priv-a1", "subnet-priv-b"))
+			Expect(cfg.AvailabilityZones).To(ConsistOf("us-west-2a", "us-west-2b"))
+		})
+	})
+
+	Context("with no matching VPC", func() {
+		BeforeEach(func() {
+			vpcs = []*ec2.Vpc{}
+		})
+
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no VPC found matching selector [environment=test]"))
+		})
+	})
+
+	Context("with more than one matching VPC", func() {
+		BeforeEach(func() {
+			vpcs = append(vpcs, &ec2.Vpc{
+				VpcId:     aws.String("vpc-2"),
+				CidrBlock: aws.String("10.1.0.0/16"),
+			})
+		})
+
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matches more than one VPC (vpc-1, vpc-2)"))
+		})
+	})
+})